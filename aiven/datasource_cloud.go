@@ -0,0 +1,93 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func datasourceCloud() *schema.Resource {
+	s := cloudAttributesSchema()
+	s["project"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Identifies the project the cloud is available to.",
+	}
+	s["latitude"] = &schema.Schema{
+		Type:         schema.TypeFloat,
+		Required:     true,
+		ValidateFunc: validation.FloatBetween(-90, 90),
+		Description:  "Latitude of the location the nearest cloud is selected for.",
+	}
+	s["longitude"] = &schema.Schema{
+		Type:         schema.TypeFloat,
+		Required:     true,
+		ValidateFunc: validation.FloatBetween(-180, 180),
+		Description:  "Longitude of the location the nearest cloud is selected for.",
+	}
+	s["cloud_provider"].Computed = true
+	s["cloud_provider"].Optional = true
+	s["cloud_provider"].Description = "Only select a cloud of this cloud provider, such as `aws` or `google`."
+	s["distance_km"] = &schema.Schema{
+		Type:        schema.TypeFloat,
+		Computed:    true,
+		Description: "Distance between the location and the cloud in kilometers.",
+	}
+
+	return &schema.Resource{
+		Description: "The Cloud data source selects the cloud available to a project that is nearest to a location.",
+		ReadContext: datasourceCloudRead,
+		Schema:      s,
+	}
+}
+
+func datasourceCloudRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project := d.Get("project").(string)
+	provider := d.Get("cloud_provider").(string)
+	latitude := d.Get("latitude").(float64)
+	longitude := d.Get("longitude").(float64)
+
+	clouds, err := aivenapi.ListClouds(m.(*providerMeta).client, project)
+	if err != nil {
+		return diag.Errorf("cannot list the clouds of project %s: %s", project, err)
+	}
+
+	cloud, distance, err := nearestCloud(filterClouds(clouds, provider), latitude, longitude)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildResourceID(project, cloud.CloudName))
+	for k, v := range cloudAttributes(*cloud) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("distance_km", distance); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// nearestCloud returns the cloud that is nearest to the coordinates and the distance to it
+func nearestCloud(clouds []aivenapi.Cloud, latitude, longitude float64) (*aivenapi.Cloud, float64, error) {
+	if len(clouds) == 0 {
+		return nil, 0, fmt.Errorf("no clouds are available")
+	}
+
+	nearest, nearestDistance := &clouds[0], cloudDistanceKm(clouds[0], latitude, longitude)
+	for i := range clouds[1:] {
+		c := &clouds[i+1]
+		if distance := cloudDistanceKm(*c, latitude, longitude); distance < nearestDistance {
+			nearest, nearestDistance = c, distance
+		}
+	}
+
+	return nearest, nearestDistance, nil
+}
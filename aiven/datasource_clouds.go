@@ -0,0 +1,153 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// earthRadiusKm is the mean radius of the earth used for the distances between clouds and coordinates
+const earthRadiusKm = 6371.0
+
+// cloudAttributesSchema returns the computed attributes of a cloud
+func cloudAttributesSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cloud_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of the cloud, used as the `cloud_name` of services.",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Description of the cloud.",
+		},
+		"cloud_provider": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Cloud provider, such as `aws`, `azure`, `do`, `google` or `upcloud`.",
+		},
+		"cloud_provider_description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Description of the cloud provider.",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Region of the cloud provider, such as `europe-west1`.",
+		},
+		"geo_region": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Geographical region of the cloud, such as `europe`.",
+		},
+		"geo_latitude": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Latitude of the cloud.",
+		},
+		"geo_longitude": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Longitude of the cloud.",
+		},
+	}
+}
+
+func datasourceClouds() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Clouds data source lists the clouds available to a project.",
+		ReadContext: datasourceCloudsRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Identifies the project the clouds are available to.",
+			},
+			"cloud_provider": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the clouds of this cloud provider, such as `aws` or `google`.",
+			},
+			"clouds": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The clouds ordered by name.",
+				Elem:        &schema.Resource{Schema: cloudAttributesSchema()},
+			},
+		},
+	}
+}
+
+func datasourceCloudsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project := d.Get("project").(string)
+	provider := d.Get("cloud_provider").(string)
+
+	clouds, err := aivenapi.ListClouds(m.(*providerMeta).client, project)
+	if err != nil {
+		return diag.Errorf("cannot list the clouds of project %s: %s", project, err)
+	}
+
+	var result []map[string]interface{}
+	for _, c := range filterClouds(clouds, provider) {
+		result = append(result, cloudAttributes(c))
+	}
+
+	if provider != "" {
+		d.SetId(buildResourceID(project, provider))
+	} else {
+		d.SetId(project)
+	}
+	if err := d.Set("clouds", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// filterClouds returns the clouds of a provider ordered by name, all the clouds are returned when
+// the provider is empty
+func filterClouds(clouds []aivenapi.Cloud, provider string) []aivenapi.Cloud {
+	var result []aivenapi.Cloud
+	for _, c := range clouds {
+		if provider == "" || c.Provider == provider {
+			result = append(result, c)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CloudName < result[j].CloudName
+	})
+	return result
+}
+
+func cloudAttributes(c aivenapi.Cloud) map[string]interface{} {
+	return map[string]interface{}{
+		"cloud_name":                 c.CloudName,
+		"description":                c.CloudDescription,
+		"cloud_provider":             c.Provider,
+		"cloud_provider_description": c.ProviderDescription,
+		"region":                     strings.TrimPrefix(c.CloudName, c.Provider+"-"),
+		"geo_region":                 c.GeoRegion,
+		"geo_latitude":               c.GeoLatitude,
+		"geo_longitude":              c.GeoLongitude,
+	}
+}
+
+// cloudDistanceKm returns the great-circle distance between a cloud and coordinates in kilometers
+func cloudDistanceKm(c aivenapi.Cloud, latitude, longitude float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	lat1, lat2 := toRadians(c.GeoLatitude), toRadians(latitude)
+	dLat, dLon := lat2-lat1, toRadians(longitude-c.GeoLongitude)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+var testClouds = []aivenapi.Cloud{
+	{CloudName: "google-europe-west1", Provider: "google", GeoLatitude: 50.4, GeoLongitude: 3.8},
+	{CloudName: "aws-eu-north-1", Provider: "aws", GeoLatitude: 59.3, GeoLongitude: 18.1},
+	{CloudName: "google-europe-north1", Provider: "google", GeoLatitude: 60.5, GeoLongitude: 27.2},
+	{CloudName: "aws-us-east-1", Provider: "aws", GeoLatitude: 38.1, GeoLongitude: -78.7},
+}
+
+func Test_filterClouds(t *testing.T) {
+	names := func(clouds []aivenapi.Cloud) []string {
+		var result []string
+		for _, c := range clouds {
+			result = append(result, c.CloudName)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"aws-eu-north-1", "aws-us-east-1", "google-europe-north1", "google-europe-west1"},
+		names(filterClouds(testClouds, "")))
+	assert.Equal(t, []string{"google-europe-north1", "google-europe-west1"}, names(filterClouds(testClouds, "google")))
+	assert.Empty(t, filterClouds(testClouds, "azure"))
+}
+
+func Test_nearestCloud(t *testing.T) {
+	// Helsinki
+	c, distance, err := nearestCloud(testClouds, 60.17, 24.94)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "google-europe-north1", c.CloudName)
+		assert.InDelta(t, 130, distance, 10)
+	}
+
+	c, _, err = nearestCloud(filterClouds(testClouds, "aws"), 60.17, 24.94)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "aws-eu-north-1", c.CloudName)
+	}
+
+	_, _, err = nearestCloud(nil, 60.17, 24.94)
+	assert.Error(t, err)
+}
+
+func Test_cloudDistanceKm(t *testing.T) {
+	c := aivenapi.Cloud{GeoLatitude: 0, GeoLongitude: 0}
+
+	assert.InDelta(t, 0, cloudDistanceKm(c, 0, 0), 0.001)
+	assert.InDelta(t, 20015, cloudDistanceKm(c, 0, 180), 1)
+	assert.InDelta(t, 10007.5, cloudDistanceKm(c, 90, 0), 1)
+}
+
+func Test_checkCloudName(t *testing.T) {
+	assert.NoError(t, checkCloudName(testClouds, "google-europe-west1"))
+
+	err := checkCloudName(testClouds, "google-europe-west9")
+	if assert.Error(t, err) {
+		assert.Equal(t, "cloud_name: google-europe-west9 is not an available cloud, the available clouds of the "+
+			"provider are google-europe-north1, google-europe-west1", err.Error())
+	}
+
+	err = checkCloudName(testClouds, "azure-westeurope")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "the `aiven_clouds` data source lists the clouds")
+	}
+}
+
+func TestAccAivenCloudDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudDataSource(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.aiven_clouds.google", "clouds.0.cloud_name"),
+					resource.TestCheckResourceAttr("data.aiven_clouds.google", "clouds.0.cloud_provider", "google"),
+					resource.TestCheckResourceAttr("data.aiven_cloud.helsinki", "cloud_name", "google-europe-north1"),
+					resource.TestCheckResourceAttr("data.aiven_cloud.helsinki", "region", "europe-north1"),
+					resource.TestCheckResourceAttrSet("data.aiven_cloud.helsinki", "distance_km"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCloudDataSource() string {
+	return fmt.Sprintf(`
+		data "aiven_clouds" "google" {
+		  project        = "%[1]s"
+		  cloud_provider = "google"
+		}
+
+		data "aiven_cloud" "helsinki" {
+		  project        = "%[1]s"
+		  cloud_provider = "google"
+		  latitude       = 60.17
+		  longitude      = 24.94
+		}`,
+		os.Getenv("AIVEN_PROJECT_NAME"))
+}
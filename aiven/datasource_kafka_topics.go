@@ -0,0 +1,241 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"regexp"
+	"sort"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func datasourceKafkaTopics() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Kafka Topics data source lists the topics of a Kafka service, including the topics that are not managed by Terraform. " +
+			"The topics can be filtered by name, tags and cleanup policy.",
+		ReadContext: datasourceKafkaTopicsRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Identifies the project the service belongs to.",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the Kafka service.",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Regular expression the names of the topics must match.",
+			},
+			"cleanup_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(kafkaTopicCleanupPolicies, false),
+				Description:  "Cleanup policy the topics must have, one of `delete`, `compact` or `compact,delete`.",
+			},
+			"tag": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Tags the topics must have, a topic matches when it has all of them.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Topic tag key.",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Topic tag value, any value of the key matches when it is empty.",
+						},
+					},
+				},
+			},
+			"topic_names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the topics that match the filters, in alphabetical order.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"topics": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Topics that match the filters, in alphabetical order of their names.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"topic_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the topic.",
+						},
+						"partitions": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of partitions of the topic.",
+						},
+						"replication": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The replication factor of the topic.",
+						},
+						"min_insync_replicas": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Minimum required nodes in-sync replicas (ISR) to produce to a partition.",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "State of the topic, such as `ACTIVE` or `CONFIGURING`.",
+						},
+						"tag": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Description: "Kafka Topic tag.",
+							Elem: &schema.Resource{
+								Schema: resourceSchemaAsDatasourceSchema(aivenKafkaTopicSchema["tag"].Elem.(*schema.Resource).Schema),
+							},
+						},
+						"config": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Kafka topic configuration",
+							Elem: &schema.Resource{
+								Schema: resourceSchemaAsDatasourceSchema(aivenKafkaTopicSchema["config"].Elem.(*schema.Resource).Schema),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// kafkaTopicsFilter selects the topics of the data source
+type kafkaTopicsFilter struct {
+	nameRegex     *regexp.Regexp
+	cleanupPolicy string
+	tags          []aiven.KafkaTopicTag
+}
+
+func datasourceKafkaTopicsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	filter := kafkaTopicsFilter{
+		cleanupPolicy: d.Get("cleanup_policy").(string),
+		tags:          getTags(d),
+	}
+	if v := d.Get("name_regex").(string); v != "" {
+		r, err := regexp.Compile(v)
+		if err != nil {
+			return diag.Errorf("invalid name_regex: %s", err)
+		}
+		filter.nameRegex = r
+	}
+
+	list, err := client.KafkaTopics.List(projectName, serviceName)
+	if err != nil {
+		return diag.Errorf("cannot list the topics of service %s/%s: %s", projectName, serviceName, err)
+	}
+
+	// the tags and the configuration of the topics are only returned by the v2 endpoint, the list
+	// is narrowed down first so that only the matching topics are retrieved through the cache
+	var names []string
+	for _, t := range list {
+		if filter.matchesListTopic(t) {
+			names = append(names, t.TopicName)
+		}
+	}
+
+	topicCache := m.(*providerMeta).topicCache
+	if err := topicCache.Refresh(projectName, serviceName, names...); err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot get the topics of service %s/%s: %s", projectName, serviceName, err)
+	}
+
+	// the topics that are still being configured are included, a topic that is deleted after it was
+	// listed is not found
+	cached, _ := topicCache.LoadByProjectAndServiceName(projectName, serviceName)
+	var topics []aiven.KafkaTopic
+	for _, name := range names {
+		if t, ok := cached[name]; ok && filter.matchesTags(t) {
+			topics = append(topics, t)
+		}
+	}
+
+	d.SetId(buildResourceID(projectName, serviceName))
+	for k, v := range kafkaTopicsAttributes(topics) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// matchesListTopic checks if a topic of the topic list matches the name and cleanup policy filters
+func (f kafkaTopicsFilter) matchesListTopic(t *aiven.KafkaListTopic) bool {
+	if f.nameRegex != nil && !f.nameRegex.MatchString(t.TopicName) {
+		return false
+	}
+
+	return f.cleanupPolicy == "" || f.cleanupPolicy == t.CleanupPolicy
+}
+
+// matchesTags checks if a topic has all the tags of the filter, a tag without a value matches any
+// value of the key
+func (f kafkaTopicsFilter) matchesTags(t aiven.KafkaTopic) bool {
+	for _, want := range f.tags {
+		found := false
+		for _, tag := range t.Tags {
+			if tag.Key == want.Key && (want.Value == "" || tag.Value == want.Value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// kafkaTopicsAttributes returns the attributes of the data source for the topics, in alphabetical
+// order of their names
+func kafkaTopicsAttributes(topics []aiven.KafkaTopic) map[string]interface{} {
+	sorted := append([]aiven.KafkaTopic(nil), topics...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].TopicName < sorted[j].TopicName
+	})
+
+	names := make([]string, 0, len(sorted))
+	list := make([]map[string]interface{}, 0, len(sorted))
+	for _, t := range sorted {
+		names = append(names, t.TopicName)
+		list = append(list, map[string]interface{}{
+			"topic_name":          t.TopicName,
+			"partitions":          len(t.Partitions),
+			"replication":         t.Replication,
+			"min_insync_replicas": t.Config.MinInsyncReplicas.Value,
+			"state":               t.State,
+			"tag":                 flattenKafkaTopicTags(t.Tags),
+			"config":              flattenKafkaTopicConfig(t),
+		})
+	}
+
+	return map[string]interface{}{
+		"topic_names": names,
+		"topics":      list,
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_kafkaTopicsFilter(t *testing.T) {
+	f := kafkaTopicsFilter{nameRegex: regexp.MustCompile("^orders-"), cleanupPolicy: "compact"}
+	assert.True(t, f.matchesListTopic(&aiven.KafkaListTopic{TopicName: "orders-eu", CleanupPolicy: "compact"}))
+	assert.False(t, f.matchesListTopic(&aiven.KafkaListTopic{TopicName: "orders-eu", CleanupPolicy: "delete"}))
+	assert.False(t, f.matchesListTopic(&aiven.KafkaListTopic{TopicName: "payments", CleanupPolicy: "compact"}))
+	assert.True(t, kafkaTopicsFilter{}.matchesListTopic(&aiven.KafkaListTopic{TopicName: "payments"}))
+
+	f = kafkaTopicsFilter{tags: []aiven.KafkaTopicTag{{Key: "owner"}, {Key: "env", Value: "prod"}}}
+	assert.True(t, f.matchesTags(aiven.KafkaTopic{Tags: []aiven.KafkaTopicTag{
+		{Key: "owner", Value: "team-a"}, {Key: "env", Value: "prod"},
+	}}))
+	assert.False(t, f.matchesTags(aiven.KafkaTopic{Tags: []aiven.KafkaTopicTag{
+		{Key: "owner", Value: "team-a"}, {Key: "env", Value: "dev"},
+	}}))
+	assert.False(t, f.matchesTags(aiven.KafkaTopic{Tags: []aiven.KafkaTopicTag{{Key: "env", Value: "prod"}}}))
+	assert.True(t, kafkaTopicsFilter{}.matchesTags(aiven.KafkaTopic{}))
+}
+
+func Test_kafkaTopicsAttributes(t *testing.T) {
+	a := kafkaTopicsAttributes([]aiven.KafkaTopic{
+		{TopicName: "b", Replication: 2, State: "ACTIVE", Partitions: partitions(3)},
+		{TopicName: "a", Replication: 3, State: "CONFIGURING", Tags: []aiven.KafkaTopicTag{{Key: "owner", Value: "team-a"}}},
+	})
+
+	assert.Equal(t, []string{"a", "b"}, a["topic_names"])
+	topics := a["topics"].([]map[string]interface{})
+	if assert.Len(t, topics, 2) {
+		assert.Equal(t, "a", topics[0]["topic_name"])
+		assert.Equal(t, []map[string]interface{}{{"key": "owner", "value": "team-a"}}, topics[0]["tag"])
+		assert.Equal(t, 3, topics[1]["partitions"])
+		assert.Equal(t, 2, topics[1]["replication"])
+	}
+
+	a = kafkaTopicsAttributes(nil)
+	assert.Empty(t, a["topic_names"])
+	assert.Empty(t, a["topics"])
+}
+
+func TestAccAivenKafkaTopicsDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_kafka_topics.topics"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenKafkaTopicResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKafkaTopicsDataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "topic_names.#", "1"),
+					resource.TestCheckResourceAttr(datasourceName, "topic_names.0", fmt.Sprintf("test-acc-topic-a-%s", rName)),
+					resource.TestCheckResourceAttr(datasourceName, "topics.0.partitions", "3"),
+					resource.TestCheckResourceAttr(datasourceName, "topics.0.replication", "2"),
+					resource.TestCheckResourceAttr(datasourceName, "topics.0.state", "ACTIVE"),
+					resource.TestCheckResourceAttr(datasourceName, "topics.0.config.0.cleanup_policy", "compact"),
+					resource.TestCheckResourceAttr(datasourceName, "topics.0.tag.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKafkaTopicsDataSource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+		  project = "%s"
+		}
+
+		resource "aiven_kafka" "bar" {
+		  project      = data.aiven_project.foo.project
+		  cloud_name   = "google-europe-west1"
+		  plan         = "business-4"
+		  service_name = "test-acc-sr-%s"
+		}
+
+		resource "aiven_kafka_topic" "a" {
+		  project      = aiven_kafka.bar.project
+		  service_name = aiven_kafka.bar.service_name
+		  topic_name   = "test-acc-topic-a-%s"
+		  partitions   = 3
+		  replication  = 2
+
+		  config {
+		    cleanup_policy = "compact"
+		  }
+
+		  tag {
+		    key   = "owner"
+		    value = "team-a"
+		  }
+		}
+
+		resource "aiven_kafka_topic" "b" {
+		  project      = aiven_kafka.bar.project
+		  service_name = aiven_kafka.bar.service_name
+		  topic_name   = "test-acc-topic-b-%s"
+		  partitions   = 3
+		  replication  = 2
+
+		  config {
+		    cleanup_policy = "compact"
+		  }
+		}
+
+		data "aiven_kafka_topics" "topics" {
+		  project        = aiven_kafka.bar.project
+		  service_name   = aiven_kafka.bar.service_name
+		  name_regex     = "^test-acc-topic-"
+		  cleanup_policy = "compact"
+
+		  tag {
+		    key = "owner"
+		  }
+
+		  depends_on = [aiven_kafka_topic.a, aiven_kafka_topic.b]
+		}`,
+		os.Getenv("AIVEN_PROJECT_NAME"), name, name, name)
+}
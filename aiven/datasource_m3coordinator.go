@@ -0,0 +1,14 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceM3Coordinator() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceServiceRead,
+		Description: "The M3 Coordinator data source provides information about the existing Aiven M3 Coordinator.",
+		Schema:      resourceSchemaAsDatasourceSchema(aivenM3CoordinatorSchema(), "project", "service_name"),
+	}
+}
@@ -5,7 +5,6 @@ package aiven
 import (
 	"context"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -20,7 +19,7 @@ func datasourceService() *schema.Resource {
 }
 
 func datasourceServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -33,7 +32,12 @@ func datasourceServiceRead(ctx context.Context, d *schema.ResourceData, m interf
 
 	for _, service := range services {
 		if service.Name == serviceName {
-			return resourceServiceRead(ctx, d, m)
+			if diags := resourceServiceRead(ctx, d, m); diags.HasError() {
+				return diags
+			}
+
+			// data sources expose the complete user configuration as JSON as well
+			return diag.FromErr(setDatasourceUserConfigJSON(d, "service", service.Type, service.UserConfig))
 		}
 	}
 
@@ -0,0 +1,176 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// servicePITRRecoveryMode is the recovery mode of the backup configuration of the plans that support
+// point-in-time recovery
+const servicePITRRecoveryMode = "pitr"
+
+func datasourceServiceBackups() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Service Backups data source provides the backups of a service and the restore points they make available.",
+		ReadContext: datasourceServiceBackupsRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Identifies the project the service belongs to.",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the service.",
+			},
+			"backups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The backups of the service ordered by backup time, the oldest first.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"backup_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the backup, used as the `backup_name` of the `restore` block of a service.",
+						},
+						"backup_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time the backup was taken.",
+						},
+						"data_size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Size of the backup in bytes.",
+						},
+						"storage_location": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Location the backup is stored in.",
+						},
+					},
+				},
+			},
+			"recovery_mode": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Recovery mode of the backups of the service plan, `pitr` when the service can be restored to a point in time and `basic` when it can only be restored from a backup.",
+			},
+			"base_backup_available": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the service has a backup it can be restored or forked from.",
+			},
+			"pitr_available": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the service can be restored or forked to a point in time between `pitr_earliest_time` and the current time.",
+			},
+			"latest_backup_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the latest backup, empty when the service has no backups.",
+			},
+			"pitr_earliest_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Earliest time the service can be restored or forked to, which is the time of the oldest backup. Empty when point-in-time recovery is not available.",
+			},
+		},
+	}
+}
+
+func datasourceServiceBackupsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	s, err := client.Services.Get(projectName, serviceName)
+	if err != nil {
+		return diag.Errorf("cannot get service %s/%s: %s", projectName, serviceName, err)
+	}
+
+	plan, err := aivenapi.GetServicePlan(client, projectName, s.Type, s.Plan)
+	if err != nil {
+		return diag.Errorf("cannot get plan %s of service type %s: %s", s.Plan, s.Type, err)
+	}
+
+	backups, err := aivenapi.ListServiceBackups(client, projectName, serviceName)
+	if err != nil {
+		return diag.Errorf("cannot list the backups of service %s/%s: %s", projectName, serviceName, err)
+	}
+
+	sorted, err := sortServiceBackups(backups)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildResourceID(projectName, serviceName))
+	for k, v := range serviceBackupsAttributes(sorted, plan.BackupConfig.RecoveryMode) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// sortServiceBackups returns the backups ordered by backup time, the oldest first
+func sortServiceBackups(backups []aivenapi.ServiceBackup) ([]aivenapi.ServiceBackup, error) {
+	times := make(map[string]time.Time, len(backups))
+	for _, b := range backups {
+		t, err := time.Parse(time.RFC3339Nano, b.BackupTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid backup time %q: %w", b.BackupTime, err)
+		}
+		times[b.BackupTime] = t
+	}
+
+	sorted := append([]aivenapi.ServiceBackup(nil), backups...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return times[sorted[i].BackupTime].Before(times[sorted[j].BackupTime])
+	})
+	return sorted, nil
+}
+
+// serviceBackupsAttributes returns the attributes of the data source for the backups ordered by
+// backup time, point-in-time recovery is available from the oldest backup when the plan supports it
+func serviceBackupsAttributes(backups []aivenapi.ServiceBackup, recoveryMode string) map[string]interface{} {
+	var list []map[string]interface{}
+	for _, b := range backups {
+		list = append(list, map[string]interface{}{
+			"backup_name":      b.BackupName,
+			"backup_time":      b.BackupTime,
+			"data_size":        b.DataSize,
+			"storage_location": b.StorageLocation,
+		})
+	}
+
+	var latestBackupTime, pitrEarliestTime string
+	if len(backups) > 0 {
+		latestBackupTime = backups[len(backups)-1].BackupTime
+	}
+	pitrAvailable := len(backups) > 0 && recoveryMode == servicePITRRecoveryMode
+	if pitrAvailable {
+		pitrEarliestTime = backups[0].BackupTime
+	}
+
+	return map[string]interface{}{
+		"backups":               list,
+		"recovery_mode":         recoveryMode,
+		"base_backup_available": len(backups) > 0,
+		"pitr_available":        pitrAvailable,
+		"latest_backup_time":    latestBackupTime,
+		"pitr_earliest_time":    pitrEarliestTime,
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_serviceBackupsAttributes(t *testing.T) {
+	backups, err := sortServiceBackups([]aivenapi.ServiceBackup{
+		{BackupName: "b", BackupTime: "2022-01-11T10:00:00.000000Z", DataSize: 2048},
+		{BackupName: "a", BackupTime: "2022-01-10T10:00:00.000000Z", DataSize: 1024},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "a", backups[0].BackupName)
+
+	a := serviceBackupsAttributes(backups, "pitr")
+	assert.Len(t, a["backups"], 2)
+	assert.Equal(t, true, a["base_backup_available"])
+	assert.Equal(t, true, a["pitr_available"])
+	assert.Equal(t, "2022-01-11T10:00:00.000000Z", a["latest_backup_time"])
+	assert.Equal(t, "2022-01-10T10:00:00.000000Z", a["pitr_earliest_time"])
+
+	a = serviceBackupsAttributes(backups, "basic")
+	assert.Equal(t, false, a["pitr_available"])
+	assert.Equal(t, "", a["pitr_earliest_time"])
+
+	a = serviceBackupsAttributes(nil, "pitr")
+	assert.Equal(t, false, a["base_backup_available"])
+	assert.Equal(t, false, a["pitr_available"])
+	assert.Equal(t, "", a["latest_backup_time"])
+
+	_, err = sortServiceBackups([]aivenapi.ServiceBackup{{BackupTime: "yesterday"}})
+	assert.Error(t, err)
+}
+
+func TestAccAivenServiceBackupsDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_service_backups.backups"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceBackupsDataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "base_backup_available", "true"),
+					resource.TestCheckResourceAttr(datasourceName, "recovery_mode", "pitr"),
+					resource.TestCheckResourceAttr(datasourceName, "pitr_available", "true"),
+					resource.TestCheckResourceAttrSet(datasourceName, "backups.0.backup_name"),
+					resource.TestCheckResourceAttrSet(datasourceName, "latest_backup_time"),
+					resource.TestCheckResourceAttrSet(datasourceName, "pitr_earliest_time"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServiceBackupsDataSource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+		  project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+		  project      = data.aiven_project.foo.project
+		  cloud_name   = "google-europe-west1"
+		  plan         = "startup-4"
+		  service_name = "test-acc-sr-%s"
+		}
+
+		data "aiven_service_backups" "backups" {
+		  project      = aiven_pg.bar.project
+		  service_name = aiven_pg.bar.service_name
+		}`,
+		os.Getenv("AIVEN_PROJECT_NAME"), name)
+}
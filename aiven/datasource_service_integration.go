@@ -6,7 +6,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -21,7 +20,7 @@ func datasourceServiceIntegration() *schema.Resource {
 }
 
 func datasourceServiceIntegrationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName := d.Get("project").(string)
 	integrationType := d.Get("integration_type").(string)
@@ -43,7 +42,11 @@ func datasourceServiceIntegrationRead(ctx context.Context, d *schema.ResourceDat
 			*i.DestinationService == destinationServiceName {
 
 			d.SetId(buildResourceID(projectName, i.ServiceIntegrationID))
-			return resourceServiceIntegrationRead(ctx, d, m)
+			if diags := resourceServiceIntegrationRead(ctx, d, m); diags.HasError() {
+				return diags
+			}
+
+			return diag.FromErr(setDatasourceUserConfigJSON(d, "integration", i.IntegrationType, i.UserConfig))
 		}
 	}
 
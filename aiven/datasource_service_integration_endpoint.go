@@ -5,7 +5,6 @@ package aiven
 import (
 	"context"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -20,7 +19,7 @@ func datasourceServiceIntegrationEndpoint() *schema.Resource {
 }
 
 func datasourceServiceIntegrationEndpointRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName := d.Get("project").(string)
 	endpointName := d.Get("endpoint_name").(string)
@@ -33,7 +32,11 @@ func datasourceServiceIntegrationEndpointRead(ctx context.Context, d *schema.Res
 	for _, endpoint := range endpoints {
 		if endpoint.EndpointName == endpointName {
 			d.SetId(buildResourceID(projectName, endpoint.EndpointID))
-			return resourceServiceIntegrationEndpointRead(ctx, d, m)
+			if diags := resourceServiceIntegrationEndpointRead(ctx, d, m); diags.HasError() {
+				return diags
+			}
+
+			return diag.FromErr(setDatasourceUserConfigJSON(d, "endpoint", endpoint.EndpointType, endpoint.UserConfig))
 		}
 	}
 
@@ -0,0 +1,69 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceServicePlan() *schema.Resource {
+	s := servicePlanSelectorSchema()
+	for k, v := range servicePlanAttributesSchema() {
+		s[k] = v
+	}
+	for _, k := range servicePlanFilterKeys {
+		s[k].ConflictsWith = []string{"service_plan"}
+	}
+	s["service_plan"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		Description: "Name of the plan, such as `business-8`. The cheapest plan that matches the filters is " +
+			"selected when it is not set.",
+	}
+
+	return &schema.Resource{
+		Description: "The Service Plan data source provides the node size, backup configuration and prices of a plan of a service type in a cloud, " +
+			"the plan is selected by its name or as the cheapest plan that matches the filters.",
+		ReadContext: datasourceServicePlanRead,
+		Schema:      s,
+	}
+}
+
+func datasourceServicePlanRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project := d.Get("project").(string)
+	serviceType := d.Get("service_type").(string)
+	cloudName := d.Get("cloud_name").(string)
+	servicePlan := d.Get("service_plan").(string)
+
+	f := servicePlanFilterFromData(d)
+	f.servicePlan = servicePlan
+	plans, err := listServicePlansInCloud(client, project, serviceType, cloudName, f)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(plans) == 0 {
+		if servicePlan != "" {
+			return diag.Errorf("plan %s of service type %s is not available in cloud %s", servicePlan, serviceType, cloudName)
+		}
+		return diag.Errorf("no plan of service type %s in cloud %s matches the filters", serviceType, cloudName)
+	}
+	plan := plans[0]
+
+	d.SetId(buildResourceID(project, serviceType, cloudName, plan.ServicePlan))
+	if err := d.Set("service_plan", plan.ServicePlan); err != nil {
+		return diag.FromErr(err)
+	}
+	for k, v := range plan.attributes() {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,399 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/aiven/terraform-provider-aiven/pkg/service"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// servicePlanFilterKeys are the attributes of the service plan data sources that filter the plans
+var servicePlanFilterKeys = []string{
+	"min_node_count",
+	"min_node_cpu_count",
+	"min_node_memory_mb",
+	"min_disk_space_mb",
+	"max_base_price_monthly_usd",
+}
+
+// servicePlanSelectorSchema returns the attributes that select the plans of a service type in a cloud
+func servicePlanSelectorSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"project": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Identifies the project the plans are available in.",
+		},
+		"service_type": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Service type of the plans, such as `pg` or `kafka`.",
+		},
+		"cloud_name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Cloud the node sizes and prices of the plans are for. Plans that are not available in the cloud are ignored.",
+		},
+		"min_node_count": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Only select plans with at least this many nodes.",
+		},
+		"min_node_cpu_count": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Only select plans with at least this many CPUs per node.",
+		},
+		"min_node_memory_mb": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Only select plans with at least this much memory per node in megabytes.",
+		},
+		"min_disk_space_mb": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Only select plans with at least this much disk space in megabytes, including the extra disk space the plan can be extended with.",
+		},
+		"max_base_price_monthly_usd": {
+			Type:         schema.TypeFloat,
+			Optional:     true,
+			ValidateFunc: validation.FloatAtLeast(0),
+			Description:  "Only select plans with a monthly base price in USD of at most this amount.",
+		},
+	}
+}
+
+// servicePlanAttributesSchema returns the computed attributes of a plan in a cloud
+func servicePlanAttributesSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"node_count": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of nodes of the plan.",
+		},
+		"node_cpu_count": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of CPUs of each node.",
+		},
+		"node_memory_mb": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Memory of each node in megabytes.",
+		},
+		"disk_space_mb": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Default disk space of the plan in megabytes.",
+		},
+		"disk_space_step_mb": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Step the disk space of the plan can be changed by in megabytes.",
+		},
+		"disk_space_cap_mb": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Maximum disk space of the plan in megabytes.",
+		},
+		"backup_config": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Backup configuration of the plan.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"interval_hours": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Interval of the backups in hours.",
+					},
+					"max_count": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Maximum number of backups kept.",
+					},
+					"recovery_mode": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Recovery mode of the backups, `basic` or `pitr` for point-in-time recovery.",
+					},
+				},
+			},
+		},
+		"base_price_hourly_usd": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Hourly base price of the plan in USD.",
+		},
+		"base_price_monthly_usd": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Monthly base price of the plan in USD, based on 730 hours a month.",
+		},
+		"extra_disk_price_per_gb_hourly_usd": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Hourly price of each gigabyte of extra disk space in USD, 0 when the disk space of the plan cannot be extended.",
+		},
+		"extra_disk_price_per_gb_monthly_usd": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Monthly price of each gigabyte of extra disk space in USD, 0 when the disk space of the plan cannot be extended.",
+		},
+	}
+}
+
+func datasourceServicePlans() *schema.Resource {
+	s := servicePlanSelectorSchema()
+	s["service_plans"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The plans that match the filters, from the cheapest to the most expensive.",
+		Elem: &schema.Resource{
+			Schema: func() map[string]*schema.Schema {
+				p := servicePlanAttributesSchema()
+				p["service_plan"] = &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the plan.",
+				}
+				return p
+			}(),
+		},
+	}
+
+	return &schema.Resource{
+		Description: "The Service Plans data source provides the node sizes, backup configuration and prices of the plans of a service type in a cloud.",
+		ReadContext: datasourceServicePlansRead,
+		Schema:      s,
+	}
+}
+
+func datasourceServicePlansRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project := d.Get("project").(string)
+	serviceType := d.Get("service_type").(string)
+	cloudName := d.Get("cloud_name").(string)
+
+	plans, err := listServicePlansInCloud(client, project, serviceType, cloudName, servicePlanFilterFromData(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var servicePlans []map[string]interface{}
+	for _, p := range plans {
+		attributes := p.attributes()
+		attributes["service_plan"] = p.ServicePlan
+		servicePlans = append(servicePlans, attributes)
+	}
+
+	d.SetId(buildResourceID(project, serviceType, cloudName))
+	if err := d.Set("service_plans", servicePlans); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// servicePlanFilter are the name, the minimum sizes and the maximum price of the selected plans, zero
+// values are not checked
+type servicePlanFilter struct {
+	servicePlan            string
+	minNodeCount           int
+	minNodeCPUCount        int
+	minNodeMemoryMB        int
+	minDiskSpaceMB         int
+	maxBasePriceMonthlyUSD *float64
+}
+
+func servicePlanFilterFromData(d *schema.ResourceData) servicePlanFilter {
+	f := servicePlanFilter{
+		minNodeCount:    d.Get("min_node_count").(int),
+		minNodeCPUCount: d.Get("min_node_cpu_count").(int),
+		minNodeMemoryMB: d.Get("min_node_memory_mb").(int),
+		minDiskSpaceMB:  d.Get("min_disk_space_mb").(int),
+	}
+	// GetOk cannot tell a maximum price of 0 from an unset one
+	if v := rawConfigValue(resourceRawConfig(d), "max_base_price_monthly_usd"); v.IsKnown() && !v.IsNull() {
+		maxPrice := d.Get("max_base_price_monthly_usd").(float64)
+		f.maxBasePriceMonthlyUSD = &maxPrice
+	}
+
+	return f
+}
+
+// matchesPrice checks if the base price of a plan is at most the maximum price of the filter
+func (f servicePlanFilter) matchesPrice(p servicePlanInCloud) bool {
+	return f.maxBasePriceMonthlyUSD == nil || p.BasePriceHourlyUSD*service.HoursPerMonth <= *f.maxBasePriceMonthlyUSD
+}
+
+// servicePlanInCloud is a plan with the node size, disk space and prices in a cloud
+type servicePlanInCloud struct {
+	ServicePlan               string
+	NodeCount                 int
+	NodeCPUCount              int
+	NodeMemoryMB              int
+	DiskSpaceMB               int
+	DiskSpaceStepMB           int
+	DiskSpaceCapMB            int
+	BackupConfig              aivenapi.ServicePlanBackupConfig
+	PriceUSD                  string
+	BasePriceHourlyUSD        float64
+	ExtraDiskPricePerGBHourly float64
+}
+
+func (p servicePlanInCloud) attributes() map[string]interface{} {
+	return map[string]interface{}{
+		"node_count":         p.NodeCount,
+		"node_cpu_count":     p.NodeCPUCount,
+		"node_memory_mb":     p.NodeMemoryMB,
+		"disk_space_mb":      p.DiskSpaceMB,
+		"disk_space_step_mb": p.DiskSpaceStepMB,
+		"disk_space_cap_mb":  p.DiskSpaceCapMB,
+		"backup_config": []map[string]interface{}{{
+			"interval_hours": p.BackupConfig.Interval,
+			"max_count":      p.BackupConfig.MaxCount,
+			"recovery_mode":  p.BackupConfig.RecoveryMode,
+		}},
+		"base_price_hourly_usd":               p.BasePriceHourlyUSD,
+		"base_price_monthly_usd":              p.BasePriceHourlyUSD * service.HoursPerMonth,
+		"extra_disk_price_per_gb_hourly_usd":  p.ExtraDiskPricePerGBHourly,
+		"extra_disk_price_per_gb_monthly_usd": p.ExtraDiskPricePerGBHourly * service.HoursPerMonth,
+	}
+}
+
+// listServicePlansInCloud returns the plans of a service type that are available in a cloud and
+// match the filter, ordered by the base price and the name. The base prices are those of the plan
+// listing, the pricing is only fetched for the plans whose disk space can be extended or that have
+// no price in the listing.
+func listServicePlansInCloud(client *aiven.Client, project, serviceType, cloudName string, f servicePlanFilter) ([]servicePlanInCloud, error) {
+	plans, err := aivenapi.ListServicePlans(client, project, serviceType)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the plans of service type %s in project %s: %w", serviceType, project, err)
+	}
+
+	var result []servicePlanInCloud
+	for _, p := range filterServicePlans(plans, cloudName, f) {
+		if p.PriceUSD != "" {
+			if err := p.setPricing(p.PriceUSD, ""); err != nil {
+				return nil, fmt.Errorf("cannot parse the price of plan %s in cloud %s: %w", p.ServicePlan, cloudName, err)
+			}
+			if !f.matchesPrice(p) {
+				continue
+			}
+		}
+
+		if p.needsPricing() {
+			pricing, err := client.ServiceTypes.GetPlanPricing(project, serviceType, p.ServicePlan, cloudName)
+			if err != nil {
+				return nil, fmt.Errorf("cannot get the pricing of plan %s in cloud %s: %w", p.ServicePlan, cloudName, err)
+			}
+			if err := p.setPricing(pricing.BasePriceUSD, pricing.ExtraDiskPricePerGBUSD); err != nil {
+				return nil, fmt.Errorf("cannot parse the pricing of plan %s in cloud %s: %w", p.ServicePlan, cloudName, err)
+			}
+			if !f.matchesPrice(p) {
+				continue
+			}
+		}
+		result = append(result, p)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].BasePriceHourlyUSD != result[j].BasePriceHourlyUSD {
+			return result[i].BasePriceHourlyUSD < result[j].BasePriceHourlyUSD
+		}
+		return result[i].ServicePlan < result[j].ServicePlan
+	})
+
+	return result, nil
+}
+
+// filterServicePlans returns the plans that are available in a cloud and match the sizes of the
+// filter, with the node size and the disk space of the cloud
+func filterServicePlans(plans []aivenapi.ServicePlan, cloudName string, f servicePlanFilter) []servicePlanInCloud {
+	var result []servicePlanInCloud
+	for _, plan := range plans {
+		if f.servicePlan != "" && plan.ServicePlan != f.servicePlan {
+			continue
+		}
+		region, ok := plan.Regions[cloudName]
+		if !ok {
+			continue
+		}
+
+		p := servicePlanInCloud{
+			ServicePlan:     plan.ServicePlan,
+			NodeCount:       plan.NodeCount,
+			NodeCPUCount:    region.NodeCPUCount,
+			NodeMemoryMB:    region.NodeMemoryMB,
+			DiskSpaceMB:     plan.DiskSpaceMB,
+			DiskSpaceStepMB: plan.DiskSpaceStepMB,
+			DiskSpaceCapMB:  plan.DiskSpaceCapMB,
+			BackupConfig:    plan.BackupConfig,
+			PriceUSD:        region.PriceUSD,
+		}
+		if region.DiskSpaceMB > 0 {
+			p.DiskSpaceMB = region.DiskSpaceMB
+		}
+		if region.DiskSpaceCapMB > 0 {
+			p.DiskSpaceCapMB = region.DiskSpaceCapMB
+		}
+		if region.DiskSpaceStepMB > 0 {
+			p.DiskSpaceStepMB = region.DiskSpaceStepMB
+		}
+
+		maxDiskSpaceMB := p.DiskSpaceMB
+		if p.DiskSpaceCapMB > maxDiskSpaceMB {
+			maxDiskSpaceMB = p.DiskSpaceCapMB
+		}
+
+		if p.NodeCount < f.minNodeCount ||
+			p.NodeCPUCount < f.minNodeCPUCount ||
+			p.NodeMemoryMB < f.minNodeMemoryMB ||
+			maxDiskSpaceMB < f.minDiskSpaceMB {
+			continue
+		}
+		result = append(result, p)
+	}
+
+	return result
+}
+
+// diskSpaceCanBeExtended checks if the disk space of the plan can be changed in steps up to a maximum
+func (p servicePlanInCloud) diskSpaceCanBeExtended() bool {
+	return p.DiskSpaceCapMB > 0 && p.DiskSpaceStepMB > 0
+}
+
+// needsPricing checks if the prices of the plan have to be fetched from the pricing API, the extra
+// disk price is only available from it and the plan listing may not have a price for the cloud
+func (p servicePlanInCloud) needsPricing() bool {
+	return p.PriceUSD == "" || p.diskSpaceCanBeExtended()
+}
+
+// setPricing sets the prices of the plan from the pricing API response, the extra disk price is
+// empty for plans whose disk space cannot be extended
+func (p *servicePlanInCloud) setPricing(basePriceUSD, extraDiskPricePerGBUSD string) error {
+	var err error
+	if p.BasePriceHourlyUSD, err = strconv.ParseFloat(basePriceUSD, 64); err != nil {
+		return err
+	}
+	if extraDiskPricePerGBUSD == "" {
+		p.ExtraDiskPricePerGBHourly = 0
+		return nil
+	}
+	p.ExtraDiskPricePerGBHourly, err = strconv.ParseFloat(extraDiskPricePerGBUSD, 64)
+	return err
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_filterServicePlans(t *testing.T) {
+	plans := []aivenapi.ServicePlan{
+		{
+			ServicePlan: "startup-4",
+			NodeCount:   1,
+			DiskSpaceMB: 81920,
+			Regions: map[string]aivenapi.ServicePlanRegion{
+				"google-europe-west1": {NodeCPUCount: 1, NodeMemoryMB: 4096, PriceUSD: "0.0260"},
+			},
+		},
+		{
+			ServicePlan:     "business-8",
+			NodeCount:       2,
+			DiskSpaceMB:     358400,
+			DiskSpaceCapMB:  716800,
+			DiskSpaceStepMB: 30720,
+			Regions: map[string]aivenapi.ServicePlanRegion{
+				"google-europe-west1": {NodeCPUCount: 2, NodeMemoryMB: 8192},
+				"aws-eu-west-1":       {NodeCPUCount: 2, NodeMemoryMB: 8192, DiskSpaceCapMB: 1433600},
+			},
+		},
+	}
+
+	names := func(plans []servicePlanInCloud) []string {
+		var result []string
+		for _, p := range plans {
+			result = append(result, p.ServicePlan)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"startup-4", "business-8"}, names(filterServicePlans(plans, "google-europe-west1", servicePlanFilter{})))
+	assert.Equal(t, []string{"business-8"}, names(filterServicePlans(plans, "aws-eu-west-1", servicePlanFilter{})))
+	assert.Equal(t, []string{"business-8"}, names(filterServicePlans(plans, "google-europe-west1", servicePlanFilter{minNodeCount: 2})))
+	assert.Equal(t, []string{"business-8"}, names(filterServicePlans(plans, "google-europe-west1", servicePlanFilter{minNodeMemoryMB: 8192})))
+	assert.Equal(t, []string{"startup-4"}, names(filterServicePlans(plans, "google-europe-west1", servicePlanFilter{servicePlan: "startup-4"})))
+	assert.Empty(t, filterServicePlans(plans, "google-europe-west1", servicePlanFilter{minNodeCPUCount: 4}))
+	assert.Empty(t, filterServicePlans(plans, "google-europe-west1", servicePlanFilter{minDiskSpaceMB: 1000000}))
+	assert.Equal(t, []string{"business-8"}, names(filterServicePlans(plans, "aws-eu-west-1", servicePlanFilter{minDiskSpaceMB: 1000000})))
+
+	awsPlans := filterServicePlans(plans, "aws-eu-west-1", servicePlanFilter{})
+	assert.Equal(t, 1433600, awsPlans[0].DiskSpaceCapMB)
+	assert.Equal(t, 358400, awsPlans[0].DiskSpaceMB)
+	assert.True(t, awsPlans[0].diskSpaceCanBeExtended())
+
+	startupPlans := filterServicePlans(plans, "google-europe-west1", servicePlanFilter{servicePlan: "startup-4"})
+	assert.Equal(t, "0.0260", startupPlans[0].PriceUSD)
+	assert.False(t, startupPlans[0].diskSpaceCanBeExtended())
+}
+
+func Test_servicePlanInCloud_needsPricing(t *testing.T) {
+	assert.False(t, servicePlanInCloud{PriceUSD: "0.0260"}.needsPricing())
+	assert.True(t, servicePlanInCloud{PriceUSD: "0.2740", DiskSpaceCapMB: 716800, DiskSpaceStepMB: 30720}.needsPricing())
+	assert.True(t, servicePlanInCloud{}.needsPricing(), "a plan without a price in the listing should be priced with the pricing API")
+}
+
+func Test_servicePlanFilter_matchesPrice(t *testing.T) {
+	maxPrice := 20.0
+	p := servicePlanInCloud{BasePriceHourlyUSD: 0.026}
+
+	assert.True(t, servicePlanFilter{}.matchesPrice(p))
+	assert.True(t, servicePlanFilter{maxBasePriceMonthlyUSD: &maxPrice}.matchesPrice(p))
+
+	maxPrice = 10
+	assert.False(t, servicePlanFilter{maxBasePriceMonthlyUSD: &maxPrice}.matchesPrice(p))
+}
+
+func Test_servicePlanInCloud_setPricing(t *testing.T) {
+	var p servicePlanInCloud
+	if assert.NoError(t, p.setPricing("0.2740", "0.000150")) {
+		assert.Equal(t, 0.274, p.BasePriceHourlyUSD)
+		assert.Equal(t, 0.00015, p.ExtraDiskPricePerGBHourly)
+		assert.InDelta(t, 200.02, p.attributes()["base_price_monthly_usd"], 0.0001)
+	}
+
+	if assert.NoError(t, p.setPricing("0.0260", "")) {
+		assert.Equal(t, 0.026, p.BasePriceHourlyUSD)
+		assert.Equal(t, 0.0, p.ExtraDiskPricePerGBHourly)
+	}
+
+	assert.Error(t, p.setPricing("", ""))
+}
+
+func TestAccAivenServicePlanDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServicePlanDataSource(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aiven_service_plan.named", "service_plan", "business-4"),
+					resource.TestCheckResourceAttr("data.aiven_service_plan.named", "node_count", "2"),
+					resource.TestCheckResourceAttrSet("data.aiven_service_plan.named", "node_memory_mb"),
+					resource.TestCheckResourceAttrSet("data.aiven_service_plan.named", "base_price_monthly_usd"),
+					resource.TestCheckResourceAttrSet("data.aiven_service_plan.cheapest", "service_plan"),
+					resource.TestCheckResourceAttr("data.aiven_service_plan.cheapest", "node_count", "3"),
+					resource.TestCheckResourceAttrSet("data.aiven_service_plans.all", "service_plans.0.service_plan"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServicePlanDataSource() string {
+	return fmt.Sprintf(`
+		data "aiven_service_plan" "named" {
+		  project      = "%[1]s"
+		  service_type = "pg"
+		  cloud_name   = "google-europe-west1"
+		  service_plan = "business-4"
+		}
+
+		data "aiven_service_plan" "cheapest" {
+		  project        = "%[1]s"
+		  service_type   = "pg"
+		  cloud_name     = "google-europe-west1"
+		  min_node_count = 3
+		}
+
+		data "aiven_service_plans" "all" {
+		  project      = "%[1]s"
+		  service_type = "pg"
+		  cloud_name   = "google-europe-west1"
+		}`,
+		os.Getenv("AIVEN_PROJECT_NAME"))
+}
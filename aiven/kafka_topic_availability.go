@@ -10,21 +10,18 @@ import (
 	"github.com/aiven/aiven-go-client"
 	"github.com/aiven/terraform-provider-aiven/pkg/cache"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
-	"golang.org/x/sync/semaphore"
 )
 
 // KafkaTopicAvailabilityWaiter is used to refresh the Aiven Kafka Topic endpoints when
 // provisioning.
 type KafkaTopicAvailabilityWaiter struct {
-	Client      *aiven.Client
+	Cache       *cache.TopicCache
 	Project     string
 	ServiceName string
 	TopicName   string
 	Ignore404   bool
 }
 
-var kafkaTopicAvailabilitySem = semaphore.NewWeighted(1)
-
 // RefreshFunc will call the Aiven client and refresh it's state.
 func (w *KafkaTopicAvailabilityWaiter) RefreshFunc() resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
@@ -40,11 +37,10 @@ func (w *KafkaTopicAvailabilityWaiter) RefreshFunc() resource.StateRefreshFunc {
 			return nil, "WRONG_INPUT", fmt.Errorf("topic name of the kafka topic resource cannot be empty `%s`", w.TopicName)
 		}
 
-		topicCache := cache.GetTopicCache()
-		topic, ok := topicCache.LoadByTopicName(w.Project, w.ServiceName, w.TopicName)
+		topic, ok := w.Cache.LoadByTopicName(w.Project, w.ServiceName, w.TopicName)
 
 		if !ok {
-			err := w.refresh()
+			err := w.Cache.Refresh(w.Project, w.ServiceName, w.TopicName)
 
 			if err != nil {
 				aivenError, ok := err.(aiven.Error)
@@ -70,8 +66,9 @@ func (w *KafkaTopicAvailabilityWaiter) RefreshFunc() resource.StateRefreshFunc {
 				return nil, "CONFIGURING", err
 			}
 
-			topic, ok = topicCache.LoadByTopicName(w.Project, w.ServiceName, w.TopicName)
-			if !ok {
+			// a topic that is not ACTIVE yet is returned in its state and listed again on the next refresh
+			topic, ok = w.Cache.LoadByTopicName(w.Project, w.ServiceName, w.TopicName)
+			if !ok && topic.TopicName != w.TopicName {
 				return nil, "CONFIGURING", nil
 			}
 		}
@@ -82,78 +79,20 @@ func (w *KafkaTopicAvailabilityWaiter) RefreshFunc() resource.StateRefreshFunc {
 	}
 }
 
-func (w *KafkaTopicAvailabilityWaiter) refresh() error {
-	if !kafkaTopicAvailabilitySem.TryAcquire(1) {
-		log.Printf("[TRACE] Kafka Topic Availability cache refresh already in progress ...")
-		cache.GetTopicCache().AddToQueue(w.Project, w.ServiceName, w.TopicName)
-		return nil
-	}
-	defer kafkaTopicAvailabilitySem.Release(1)
-
-	c := cache.GetTopicCache()
-
-	// check if topic is already in cache
-	if _, ok := c.LoadByTopicName(w.Project, w.ServiceName, w.TopicName); ok {
-		return nil
-	}
-
-	c.AddToQueue(w.Project, w.ServiceName, w.TopicName)
-
-	for {
-		queue := c.GetQueue(w.Project, w.ServiceName)
-		if len(queue) == 0 {
-			break
-		}
-
-		log.Printf("[DEBUG] Kafka Topic queue: %+v", queue)
-		v2Topics, err := w.Client.KafkaTopics.V2List(w.Project, w.ServiceName, queue)
-		if err != nil {
-			// if v2 endpoint retrieves 409 response code, it means that Kafka service has old nodes and
-			// v2 endpoint is not available, therefore using v1.
-			if err.(aiven.Error).Status == 409 {
-				err = w.v1Refresh(queue)
-				if err != nil {
-					return err
-				}
-			}
-
-			if aiven.IsNotFound(err) {
-				return fmt.Errorf("one of the Kafka Topics from the queue [%+v] is not found: %w", queue, err)
-			}
-
-			return err
-		}
-
-		cache.GetTopicCache().StoreByProjectAndServiceName(w.Project, w.ServiceName, v2Topics)
-	}
-
-	return nil
-}
-
-func (w *KafkaTopicAvailabilityWaiter) v1Refresh(queue []string) error {
-	log.Printf("[DEBUG] Kafka Topic V2 endpoit is not available, using v1!")
-	for _, t := range queue {
-		topic, err := w.Client.KafkaTopics.Get(w.Project, w.ServiceName, t)
-		if err != nil {
-			return err
-		}
-
-		cache.GetTopicCache().StoreByProjectAndServiceName(w.Project, w.ServiceName, []*aiven.KafkaTopic{topic})
-	}
-	return nil
-}
-
 // Conf sets up the configuration to refresh.
 func (w *KafkaTopicAvailabilityWaiter) Conf(timeout time.Duration) *resource.StateChangeConf {
 	log.Printf("[DEBUG] Kafka Topic availability waiter timeout %.0f minutes", timeout.Minutes())
 
+	// the first refresh waits for the topics of the service to be listed, so an existing topic is
+	// usually found right away, a topic that is still being configured is polled with an exponential
+	// backoff from one to ten seconds
 	return &resource.StateChangeConf{
 		Pending:        []string{"CONFIGURING"},
 		Target:         []string{"ACTIVE"},
 		Refresh:        w.RefreshFunc(),
 		Timeout:        timeout,
-		PollInterval:   30 * time.Second,
-		NotFoundChecks: 50,
+		MinTimeout:     time.Second,
+		NotFoundChecks: 150,
 	}
 }
 
@@ -0,0 +1,144 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/pkg/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeKafkaTopicsClient serves the topics of every service it is asked for and records the number
+// of topics of each V2List call and the most calls of a service in flight at a time, the topics are
+// CONFIGURING for their first configuringLists listings
+type fakeKafkaTopicsClient struct {
+	sync.Mutex
+	configuringLists int
+	listed           map[string]int
+	pages            []int
+	inFlight         map[string]int
+	maxInFlight      int
+}
+
+func newFakeKafkaTopicsClient(configuringLists int) *fakeKafkaTopicsClient {
+	return &fakeKafkaTopicsClient{
+		configuringLists: configuringLists,
+		listed:           make(map[string]int),
+		inFlight:         make(map[string]int),
+	}
+}
+
+func (c *fakeKafkaTopicsClient) Get(_, _, topic string) (*aiven.KafkaTopic, error) {
+	return &aiven.KafkaTopic{TopicName: topic, State: "ACTIVE"}, nil
+}
+
+func (c *fakeKafkaTopicsClient) V2List(_, service string, topics []string) ([]*aiven.KafkaTopic, error) {
+	c.Lock()
+	c.pages = append(c.pages, len(topics))
+	c.inFlight[service]++
+	if c.inFlight[service] > c.maxInFlight {
+		c.maxInFlight = c.inFlight[service]
+	}
+	c.Unlock()
+
+	// simulate the latency of the API, so the waiters queue up topics during a call
+	time.Sleep(time.Millisecond)
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.inFlight[service]--
+
+	var result []*aiven.KafkaTopic
+	for _, name := range topics {
+		state := "ACTIVE"
+		if c.listed[service+"/"+name]++; c.listed[service+"/"+name] <= c.configuringLists {
+			state = "CONFIGURING"
+		}
+		result = append(result, &aiven.KafkaTopic{TopicName: name, State: state})
+	}
+	return result, nil
+}
+
+func TestKafkaTopicAvailabilityWaiter_configuring(t *testing.T) {
+	c := cache.NewTopicCache(newFakeKafkaTopicsClient(1), time.Hour)
+	w := &KafkaTopicAvailabilityWaiter{
+		Cache:       c,
+		Project:     "test-project",
+		ServiceName: "test-service",
+		TopicName:   "topic-0",
+	}
+
+	start := time.Now()
+	topic, err := w.Conf(time.Minute).WaitForState()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ACTIVE", topic.(aiven.KafkaTopic).State)
+	}
+	// the CONFIGURING topic is listed again on the next poll instead of being served from the cache
+	// until its TTL has passed
+	assert.Less(t, time.Since(start).Seconds(), 10.0)
+}
+
+func TestKafkaTopicAvailabilityWaiter_concurrent(t *testing.T) {
+	const services, topicsPerService = 5, 800
+
+	// the topics are CONFIGURING when they are listed for the first time, like newly created topics
+	client := newFakeKafkaTopicsClient(1)
+	c := cache.NewTopicCache(client, time.Hour)
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, services*topicsPerService)
+	for s := 0; s < services; s++ {
+		for i := 0; i < topicsPerService; i++ {
+			w := &KafkaTopicAvailabilityWaiter{
+				Cache:       c,
+				Project:     "test-project",
+				ServiceName: fmt.Sprintf("test-service-%d", s),
+				TopicName:   fmt.Sprintf("topic-%d", i),
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				// the waiters of a service share the listings of its topics, so every waiter finds
+				// its topic CONFIGURING with the first refresh and ACTIVE with the next poll
+				topic, err := w.Conf(time.Minute).WaitForState()
+				if err != nil {
+					errs <- err
+					return
+				}
+				if got := topic.(aiven.KafkaTopic); got.State != "ACTIVE" || got.TopicName != w.TopicName {
+					errs <- fmt.Errorf("got topic %v for %s/%s", got, w.ServiceName, w.TopicName)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	var listed int
+	for _, n := range client.pages {
+		assert.LessOrEqual(t, n, cache.TopicPageSize)
+		listed += n
+	}
+	assert.Equal(t, 2*services*topicsPerService, listed, "every topic should be listed once CONFIGURING and once ACTIVE")
+	assert.Less(t, len(client.pages), 2*services*topicsPerService/10, "topics should be listed in batches")
+	// the topics become ACTIVE with the first poll after the minimum timeout of one second, long
+	// before the TTL of the cache
+	assert.Less(t, time.Since(start).Seconds(), 10.0, "the topics should be found ACTIVE with the first polls")
+	assert.Equal(t, 1, client.maxInFlight, "one listing of a service should be in flight at a time")
+}
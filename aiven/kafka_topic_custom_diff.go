@@ -0,0 +1,130 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// customizeDiffKafkaTopicPartitions fails the plan when the number of partitions of a topic is
+// decreased, Kafka cannot remove partitions from a topic
+func customizeDiffKafkaTopicPartitions(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("partitions") || !d.NewValueKnown("partitions") {
+		return nil
+	}
+
+	o, n := d.GetChange("partitions")
+	if o.(int) > n.(int) {
+		return fmt.Errorf("partitions cannot be decreased from %d to %d, Kafka does not support removing partitions "+
+			"from a topic", o.(int), n.(int))
+	}
+
+	return nil
+}
+
+// customizeDiffKafkaTopicMinInsyncReplicas fails the plan when the minimum number of in-sync
+// replicas of a topic is greater than its replication factor, producing to the topic would fail
+func customizeDiffKafkaTopicMinInsyncReplicas(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("replication") {
+		return nil
+	}
+	replication := d.Get("replication").(int)
+
+	for _, k := range []string{"config.0.min_insync_replicas", "minimum_in_sync_replicas"} {
+		if !d.NewValueKnown(k) {
+			continue
+		}
+		if minInsyncReplicas := d.Get(k).(int); minInsyncReplicas > replication {
+			return fmt.Errorf("%s: %d is greater than the replication factor %d of the topic", k, minInsyncReplicas, replication)
+		}
+	}
+
+	return nil
+}
+
+// customizeDiffKafkaTopicReplication fails the plan when the replication factor of a topic is
+// greater than the number of nodes of the plan of the Kafka service, the check is skipped when the
+// service does not exist yet
+func customizeDiffKafkaTopicReplication(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChange("replication") {
+		return nil
+	}
+	meta, ok := m.(*providerMeta)
+	if !ok || meta.client == nil {
+		return nil
+	}
+	for _, k := range []string{"project", "service_name", "replication"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	nodeCount, planName, err := meta.kafkaServiceNodes.get(meta.client, d.Get("project").(string), d.Get("service_name").(string))
+	if err != nil {
+		return err
+	}
+
+	return checkKafkaTopicReplication(d.Get("replication").(int), nodeCount, planName)
+}
+
+// kafkaServiceNodes memoises the number of nodes of the Kafka services for the replication checks
+// of the topics, each service is fetched once per provider instance instead of once per topic. Only
+// the services that are found are kept, the lookups that fail or do not find the service are made
+// again by the next topic.
+type kafkaServiceNodes struct {
+	mu    sync.Mutex
+	calls map[string]*kafkaServiceNodesCall
+}
+
+type kafkaServiceNodesCall struct {
+	once      sync.Once
+	nodeCount int
+	planName  string
+	err       error
+}
+
+// get returns the number of nodes and the plan of a Kafka service, the number of nodes is zero when
+// the service does not exist yet
+func (n *kafkaServiceNodes) get(client *aiven.Client, project, serviceName string) (int, string, error) {
+	n.mu.Lock()
+	if n.calls == nil {
+		n.calls = make(map[string]*kafkaServiceNodesCall)
+	}
+	key := buildResourceID(project, serviceName)
+	call, ok := n.calls[key]
+	if !ok {
+		call = &kafkaServiceNodesCall{}
+		n.calls[key] = call
+	}
+	n.mu.Unlock()
+
+	call.once.Do(func() {
+		s, err := client.Services.Get(project, serviceName)
+		if err != nil {
+			if !aiven.IsNotFound(err) {
+				call.err = fmt.Errorf("cannot get service %s/%s: %w", project, serviceName, err)
+			}
+
+			n.mu.Lock()
+			delete(n.calls, key)
+			n.mu.Unlock()
+			return
+		}
+		call.nodeCount, call.planName = s.NodeCount, s.Plan
+	})
+
+	return call.nodeCount, call.planName, call.err
+}
+
+func checkKafkaTopicReplication(replication, nodeCount int, planName string) error {
+	if nodeCount > 0 && replication > nodeCount {
+		return fmt.Errorf("replication: %d is greater than the %d nodes of the %s plan of the Kafka service, "+
+			"each replica of a partition is stored on a different node", replication, nodeCount, planName)
+	}
+
+	return nil
+}
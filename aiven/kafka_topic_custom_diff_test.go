@@ -0,0 +1,68 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_customizeDiffKafkaTopic(t *testing.T) {
+	r := resourceKafkaTopic()
+
+	state := &terraform.InstanceState{
+		ID: "project/service/topic",
+		Attributes: map[string]string{
+			"project":                      "project",
+			"service_name":                 "service",
+			"topic_name":                   "topic",
+			"partitions":                   "3",
+			"replication":                  "3",
+			"config.#":                     "1",
+			"config.0.min_insync_replicas": "2",
+		},
+	}
+	config := func(partitions, replication, minInsyncReplicas int) map[string]interface{} {
+		return map[string]interface{}{
+			"project":      "project",
+			"service_name": "service",
+			"topic_name":   "topic",
+			"partitions":   partitions,
+			"replication":  replication,
+			"config": []interface{}{map[string]interface{}{
+				"min_insync_replicas": minInsyncReplicas,
+			}},
+		}
+	}
+	diff := func(state *terraform.InstanceState, config map[string]interface{}) error {
+		_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+		return err
+	}
+
+	assert.NoError(t, diff(state, config(6, 3, 2)))
+	assert.NoError(t, diff(state, config(3, 2, 2)))
+	assert.NoError(t, diff(nil, config(1, 1, 1)))
+
+	err := diff(state, config(2, 3, 2))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "partitions cannot be decreased from 3 to 2")
+	}
+
+	err = diff(state, config(3, 2, 3))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "config.0.min_insync_replicas: 3 is greater than the replication factor 2")
+	}
+	assert.Error(t, diff(nil, config(3, 1, 2)))
+}
+
+func Test_checkKafkaTopicReplication(t *testing.T) {
+	assert.NoError(t, checkKafkaTopicReplication(3, 3, "business-4"))
+	assert.NoError(t, checkKafkaTopicReplication(3, 0, "custom"))
+
+	err := checkKafkaTopicReplication(4, 3, "business-4")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "replication: 4 is greater than the 3 nodes of the business-4 plan")
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// kafkaTopicStateUpgraders returns the state upgraders of the Kafka topic resource. In schema
+// version 0 all the integer, number and boolean options of the config block were stored as strings.
+func kafkaTopicStateUpgraders() []schema.StateUpgrader {
+	return []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    (&schema.Resource{Schema: kafkaTopicSchemaV0()}).CoreConfigSchema().ImpliedType(),
+			Upgrade: kafkaTopicStateUpgradeV0,
+		},
+	}
+}
+
+// kafkaTopicSchemaV0 returns a copy of the resource schema where all the options of the config block
+// are strings, as they were in schema version 0
+func kafkaTopicSchemaV0() map[string]*schema.Schema {
+	result := make(map[string]*schema.Schema, len(aivenKafkaTopicSchema))
+	for k, v := range aivenKafkaTopicSchema {
+		if k == "config" {
+			v = userConfigOptionSchemaV0(v)
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// kafkaTopicStateUpgradeV0 converts string values of the integer, number and boolean options of the
+// config block to their actual types, empty strings that were used to mark unset options are removed
+func kafkaTopicStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	list, ok := rawState["config"].([]interface{})
+	if !ok {
+		return rawState, nil
+	}
+
+	options := aivenKafkaTopicSchema["config"].Elem.(*schema.Resource).Schema
+	for _, v := range list {
+		config, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for key, value := range config {
+			s, ok := value.(string)
+			if !ok || options[key] == nil {
+				continue
+			}
+
+			var valueType string
+			switch options[key].Type {
+			case schema.TypeInt:
+				valueType = "integer"
+			case schema.TypeFloat:
+				valueType = "number"
+			case schema.TypeBool:
+				valueType = "boolean"
+			default:
+				continue
+			}
+
+			if s == "" {
+				config[key] = nil
+				continue
+			}
+
+			converted, err := convertUserConfigValueToSchemaType(valueType, s)
+			if err != nil {
+				log.Printf("[WARN] cannot convert Kafka topic config option %s value %q to %s, removing it from the state: %s",
+					key, s, valueType, err)
+				converted = nil
+			}
+			config[key] = converted
+		}
+	}
+
+	return rawState, nil
+}
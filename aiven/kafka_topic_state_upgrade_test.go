@@ -0,0 +1,51 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_kafkaTopicStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"topic_name":  "test-topic",
+		"partitions":  3,
+		"replication": 2,
+		"config": []interface{}{
+			map[string]interface{}{
+				"cleanup_policy":                 "compact",
+				"compression_type":               "",
+				"retention_ms":                   "-1",
+				"segment_bytes":                  "",
+				"min_insync_replicas":            "2",
+				"min_cleanable_dirty_ratio":      "0.5",
+				"preallocate":                    "false",
+				"unclean_leader_election_enable": "",
+				"flush_ms":                       "every minute",
+			},
+		},
+	}
+
+	got, err := kafkaTopicStateUpgradeV0(context.Background(), rawState, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	config := got["config"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "compact", config["cleanup_policy"])
+	assert.Equal(t, "", config["compression_type"])
+	assert.Equal(t, -1, config["retention_ms"])
+	assert.Nil(t, config["segment_bytes"])
+	assert.Equal(t, 2, config["min_insync_replicas"])
+	assert.Equal(t, 0.5, config["min_cleanable_dirty_ratio"])
+	assert.Equal(t, false, config["preallocate"])
+	assert.Nil(t, config["unclean_leader_election_enable"])
+	assert.Nil(t, config["flush_ms"])
+
+	got, err = kafkaTopicStateUpgradeV0(context.Background(), map[string]interface{}{"topic_name": "test-topic"}, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{"topic_name": "test-topic"}, got)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
@@ -31,6 +30,29 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("AIVEN_TOKEN", nil),
 				Description: "Aiven Authentication Token",
 			},
+			"fetch_user_config_schemas": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AIVEN_FETCH_USER_CONFIG_SCHEMAS", false),
+				Description: "Fetch the user config schemas from the Aiven API instead of only using the ones embedded " +
+					"in the provider. The `<type>_user_config_json` attributes then accept options released after " +
+					"the provider, the embedded schemas are used when the API cannot be reached.",
+			},
+			"wait_for_migration": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AIVEN_WAIT_FOR_MIGRATION", false),
+				Description: "The default of the `wait_for_migration` option of the services. When enabled, service updates " +
+					"wait until a migration caused by a plan, cloud or VPC change is finished and the service is running.",
+			},
+			"kafka_topic_cache_ttl": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AIVEN_KAFKA_TOPIC_CACHE_TTL", cache.DefaultTopicCacheTTL.String()),
+				ValidateFunc: validateDurationString,
+				Description: "How long the Kafka topics retrieved from the Aiven API are cached, such as `5m`. The topics of a " +
+					"service are retrieved in batches and reused by the other `aiven_kafka_topic` resources until they expire.",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -38,6 +60,7 @@ func Provider() *schema.Provider {
 			"aiven_database":                       datasourceDatabase(),
 			"aiven_kafka_acl":                      datasourceKafkaACL(),
 			"aiven_kafka_topic":                    datasourceKafkaTopic(),
+			"aiven_kafka_topics":                   datasourceKafkaTopics(),
 			"aiven_kafka_connector":                datasourceKafkaConnector(),
 			"aiven_kafka_schema":                   datasourceKafkaSchema(),
 			"aiven_kafka_schema_configuration":     datasourceKafkaSchemaConfiguration(),
@@ -70,6 +93,7 @@ func Provider() *schema.Provider {
 			"aiven_service_component":              datasourceServiceComponent(),
 			"aiven_m3db":                           datasourceM3DB(),
 			"aiven_m3aggregator":                   datasourceM3Aggregator(),
+			"aiven_m3coordinator":                  datasourceM3Coordinator(),
 			"aiven_billing_group":                  datasourceBillingGroup(),
 			"aiven_aws_privatelink":                datasourceAWSPrivatelink(),
 			"aiven_opensearch":                     datasourceOpensearch(),
@@ -78,6 +102,11 @@ func Provider() *schema.Provider {
 			"aiven_flink":                          datasourceFlink(),
 			"aiven_azure_privatelink":              datasourceAzurePrivatelink(),
 			"aiven_clickhouse":                     datasourceClickhouse(),
+			"aiven_service_plan":                   datasourceServicePlan(),
+			"aiven_service_plans":                  datasourceServicePlans(),
+			"aiven_clouds":                         datasourceClouds(),
+			"aiven_cloud":                          datasourceCloud(),
+			"aiven_service_backups":                datasourceServiceBackups(),
 
 			// deprecated
 			"aiven_elasticsearch_acl": datasourceElasticsearchACL(),
@@ -120,6 +149,7 @@ func Provider() *schema.Provider {
 			"aiven_transit_gateway_vpc_attachment": resourceTransitGatewayVPCAttachment(),
 			"aiven_m3db":                           resourceM3DB(),
 			"aiven_m3aggregator":                   resourceM3Aggregator(),
+			"aiven_m3coordinator":                  resourceM3Coordinator(),
 			"aiven_billing_group":                  resourceBillingGroup(),
 			"aiven_aws_privatelink":                resourceAWSPrivatelink(),
 			"aiven_opensearch":                     resourceOpensearch(),
@@ -127,6 +157,8 @@ func Provider() *schema.Provider {
 			"aiven_opensearch_acl_rule":            resourceOpensearchACLRule(),
 			"aiven_azure_privatelink":              resourceAzurePrivatelink(),
 			"aiven_clickhouse":                     resourceClickhouse(),
+			"aiven_static_ip":                      resourceStaticIP(),
+			"aiven_service_maintenance":            resourceServiceMaintenance(),
 
 			// flink
 			"aiven_flink":       resourceFlink(),
@@ -140,7 +172,6 @@ func Provider() *schema.Provider {
 	}
 
 	p.ConfigureContextFunc = func(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		_ = cache.NewTopicCache()
 		terraformVersion := p.TerraformVersion
 		if terraformVersion == "" {
 			// Terraform 0.12 introduced this field to the protocol
@@ -155,7 +186,22 @@ func Provider() *schema.Provider {
 			return nil, diag.FromErr(err)
 		}
 
-		return client, nil
+		topicCacheTTL, err := time.ParseDuration(d.Get("kafka_topic_cache_ttl").(string))
+		if err != nil {
+			return nil, diag.Errorf("invalid kafka_topic_cache_ttl: %s", err)
+		}
+		if topicCacheTTL <= 0 {
+			return nil, diag.Errorf("kafka_topic_cache_ttl must be positive, got %s", topicCacheTTL)
+		}
+
+		userConfigSchemas, diags := newUserConfigSchemas(client, d.Get("fetch_user_config_schemas").(bool))
+
+		return &providerMeta{
+			client:            client,
+			topicCache:        cache.NewTopicCache(client.KafkaTopics, topicCacheTTL),
+			userConfigSchemas: userConfigSchemas,
+			waitForMigration:  d.Get("wait_for_migration").(bool),
+		}, diags
 	}
 
 	return p
@@ -205,77 +251,6 @@ func optionalIntPointer(d *schema.ResourceData, key string) *int {
 	return &intValue
 }
 
-func toOptionalString(val interface{}) string {
-	switch v := val.(type) {
-	case int:
-		return strconv.Itoa(v)
-	case int64:
-		return strconv.FormatInt(v, 10)
-	case float64:
-		return strconv.FormatFloat(v, 'f', -1, 64)
-	case bool:
-		return strconv.FormatBool(v)
-	case string:
-		return v
-	default:
-		return ""
-	}
-}
-
-func parseOptionalStringToInt64(val interface{}) *int64 {
-	v, ok := val.(string)
-	if !ok {
-		return nil
-	}
-
-	if v == "" {
-		return nil
-	}
-
-	res, err := strconv.ParseInt(v, 10, 64)
-	if err != nil {
-		return nil
-	}
-
-	return &res
-}
-
-func parseOptionalStringToFloat64(val interface{}) *float64 {
-	v, ok := val.(string)
-	if !ok {
-		return nil
-	}
-
-	if v == "" {
-		return nil
-	}
-
-	res, err := strconv.ParseFloat(v, 64)
-	if err != nil {
-		return nil
-	}
-
-	return &res
-}
-
-func parseOptionalStringToBool(val interface{}) *bool {
-	v, ok := val.(string)
-	if !ok {
-		return nil
-	}
-
-	if v == "" {
-		return nil
-	}
-
-	res, err := strconv.ParseBool(v)
-	if err != nil {
-		return nil
-	}
-
-	return &res
-}
-
 func buildResourceID(parts ...string) string {
 	finalParts := make([]string, len(parts))
 	for idx, part := range parts {
@@ -314,7 +289,7 @@ func createOnlyDiffSuppressFunc(_, _, _ string, d *schema.ResourceData) bool {
 
 // emptyObjectDiffSuppressFunc suppresses a diff for service user configuration options when
 // fields are not set by the user but have default or previously defined values.
-func emptyObjectDiffSuppressFunc(k, old, new string, _ *schema.ResourceData) bool {
+func emptyObjectDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
 	// When a map inside a list contains only default values without explicit values set by
 	// the user Terraform interprets the map as not being present and the array length being
 	// zero, resulting in bogus update that does nothing. Allow ignoring those.
@@ -328,8 +303,9 @@ func emptyObjectDiffSuppressFunc(k, old, new string, _ *schema.ResourceData) boo
 		return true
 	}
 
-	// There is a bug in Terraform 0.11 which interprets "true" as "0" and "false" as "1"
-	if (new == "0" && old == "false") || (new == "1" && old == "true") {
+	// Typed fields (int, bool, float) that are not set have a zero value instead of an empty
+	// string, the raw configuration tells those apart from zero values that are set explicitly.
+	if old != "" && d != nil && rawConfigValue(resourceRawConfig(d), k).IsNull() {
 		return true
 	}
 
@@ -0,0 +1,65 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/pkg/cache"
+	"github.com/aiven/terraform-provider-aiven/pkg/service"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// providerMeta is the meta of a configured provider instance, it is passed to all the resources
+// and data sources
+type providerMeta struct {
+	client *aiven.Client
+
+	// topicCache is the Kafka Topic cache shared by the topic resources and data sources
+	topicCache *cache.TopicCache
+
+	// userConfigSchemas are the user config schemas the user configuration is validated against
+	userConfigSchemas *userConfigSchemas
+
+	// kafkaServiceNodes are the numbers of nodes of the Kafka services the topics are checked against
+	kafkaServiceNodes kafkaServiceNodes
+
+	// planCache memoises the service plans and their pricing for the checks and cost estimates of the services
+	planCache service.PlanCache
+
+	// waitForMigration is the default of the `wait_for_migration` option of the services
+	waitForMigration bool
+}
+
+// AivenClient returns the API client of the provider instance, it gives the packages outside of
+// aiven access to the client of the meta
+func (m *providerMeta) AivenClient() *aiven.Client {
+	return m.client
+}
+
+// ServicePlanCache returns the service plan cache of the provider instance, the functions of the
+// service package memoise the plans and their pricing in it
+func (m *providerMeta) ServicePlanCache() *service.PlanCache {
+	return &m.planCache
+}
+
+// providerClient returns the API client of the provider meta, ok is false when the provider is
+// not configured, such as in the unit tests of the plan time checks
+func providerClient(m interface{}) (*aiven.Client, bool) {
+	meta, ok := m.(*providerMeta)
+	if !ok || meta == nil || meta.client == nil {
+		return nil, false
+	}
+	return meta.client, true
+}
+
+// serviceWaitForMigration checks if a service update should wait for the migration of the service
+// to finish, the provider level default is used when `wait_for_migration` is not set
+func serviceWaitForMigration(d *schema.ResourceData, m interface{}) bool {
+	v := rawConfigValue(resourceRawConfig(d), "wait_for_migration")
+	if v.IsKnown() && !v.IsNull() && v.Type() == cty.Bool {
+		return v.True()
+	}
+
+	meta, ok := m.(*providerMeta)
+	return ok && meta.waitForMigration
+}
@@ -57,7 +57,7 @@ account team you are trying to link to this project.
 }
 
 func resourceAccountTeamProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId := d.Get("account_id").(string)
 	teamId := d.Get("team_id").(string)
@@ -82,7 +82,7 @@ func resourceAccountTeamProjectCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceAccountTeamProjectRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId, teamId, projectName := splitResourceID3(d.Id())
 	r, err := client.AccountTeamProjects.List(accountId, teamId)
@@ -118,7 +118,7 @@ func resourceAccountTeamProjectRead(_ context.Context, d *schema.ResourceData, m
 }
 
 func resourceAccountTeamProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId, teamId, _ := splitResourceID3(d.Id())
 	newProjectName := d.Get("project_name").(string)
@@ -138,7 +138,7 @@ func resourceAccountTeamProjectUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceAccountTeamProjectDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	err := client.AccountTeamProjects.Delete(splitResourceID3(d.Id()))
 	if err != nil && !aiven.IsNotFound(err) {
@@ -129,7 +129,7 @@ func testAccAccountProjectDissociate(name string) string {
 }
 
 func testAccCheckAivenAccountResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each account is destroyed
 	for _, rs := range s.RootModule().Resources {
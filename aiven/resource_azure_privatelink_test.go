@@ -41,7 +41,7 @@ func TestAccAivenAzurePrivatelink_basic(t *testing.T) {
 }
 
 func testAccCheckAivenAzurePrivatelinkResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each AWS privatelink is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -5,6 +5,8 @@ package aiven
 import (
 	"time"
 
+	"github.com/aiven/terraform-provider-aiven/pkg/service"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -19,6 +21,7 @@ func cassandraSchema() map[string]*schema.Schema {
 		},
 	}
 	s[ServiceTypeCassandra+"_user_config"] = generateServiceUserConfiguration(ServiceTypeCassandra)
+	s[ServiceTypeCassandra+"_user_config_json"] = generateServiceUserConfigurationJSON(ServiceTypeCassandra)
 
 	return s
 }
@@ -30,6 +33,18 @@ func resourceCassandra() *schema.Resource {
 		ReadContext:   resourceServiceRead,
 		UpdateContext: resourceServiceUpdate,
 		DeleteContext: resourceServiceDelete,
+		CustomizeDiff: customdiff.All(
+			customdiff.Sequence(
+				service.SetServiceTypeIfEmpty(ServiceTypeCassandra),
+				service.CustomizeDiffCheckPlan(ServiceTypeCassandra),
+				service.CustomizeDiffEstimatedMonthlyCost(ServiceTypeCassandra),
+			),
+			customizeDiffUserConfigJSON("service", ServiceTypeCassandra),
+			customizeDiffUserConfigCreateOnly("service", ServiceTypeCassandra),
+			service.CustomizeDiffCheckVersionUpgrade(ServiceTypeCassandra),
+			customizeDiffServiceCloudName,
+			customizeDiffServiceStaticIPs(ServiceTypeCassandra),
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
@@ -38,6 +53,8 @@ func resourceCassandra() *schema.Resource {
 			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
-		Schema: cassandraSchema(),
+		Schema:         cassandraSchema(),
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("service", cassandraSchema()),
 	}
 }
@@ -20,6 +20,7 @@ func clickhouseSchema() map[string]*schema.Schema {
 		},
 	}
 	s[ServiceTypeClickhouse+"_user_config"] = generateServiceUserConfiguration(ServiceTypeClickhouse)
+	s[ServiceTypeClickhouse+"_user_config_json"] = generateServiceUserConfigurationJSON(ServiceTypeClickhouse)
 
 	return s
 }
@@ -34,6 +35,8 @@ func resourceClickhouse() *schema.Resource {
 		CustomizeDiff: customdiff.All(
 			customdiff.Sequence(
 				service.SetServiceTypeIfEmpty(ServiceTypeClickhouse),
+				service.CustomizeDiffCheckPlan(ServiceTypeClickhouse),
+				service.CustomizeDiffEstimatedMonthlyCost(ServiceTypeClickhouse),
 				customdiff.IfValueChange("disk_space",
 					service.DiskSpaceShouldNotBeEmpty,
 					service.CustomizeDiffCheckDiskSpace),
@@ -41,6 +44,10 @@ func resourceClickhouse() *schema.Resource {
 			customdiff.IfValueChange("service_integrations",
 				service.ServiceIntegrationShouldNotBeEmpty,
 				service.CustomizeDiffServiceIntegrationAfterCreation),
+			customizeDiffUserConfigJSON("service", ServiceTypeClickhouse),
+			customizeDiffUserConfigCreateOnly("service", ServiceTypeClickhouse),
+			service.CustomizeDiffCheckVersionUpgrade(ServiceTypeClickhouse),
+			customizeDiffServiceCloudName,
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
@@ -50,6 +57,8 @@ func resourceClickhouse() *schema.Resource {
 			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
-		Schema: clickhouseSchema(),
+		Schema:         clickhouseSchema(),
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("service", clickhouseSchema()),
 	}
 }
@@ -230,7 +230,7 @@ func testAccCheckAivenConnectionPoolAttributes(n string) resource.TestCheckFunc
 }
 
 func testAccCheckAivenConnectionPoolResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each connection pool is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -107,7 +107,7 @@ func TestAccAivenDatabase_basic(t *testing.T) {
 }
 
 func testAccCheckAivenDatabaseResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each database is destroyed
 	for _, rs := range s.RootModule().Resources {
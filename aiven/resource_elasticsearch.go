@@ -28,6 +28,7 @@ func elasticsearchSchema() map[string]*schema.Schema {
 		},
 	}
 	s[ServiceTypeElasticsearch+"_user_config"] = generateServiceUserConfiguration(ServiceTypeElasticsearch)
+	s[ServiceTypeElasticsearch+"_user_config_json"] = generateServiceUserConfigurationJSON(ServiceTypeElasticsearch)
 
 	return s
 }
@@ -42,6 +43,8 @@ func resourceElasticsearch() *schema.Resource {
 		CustomizeDiff: customdiff.All(
 			customdiff.Sequence(
 				service.SetServiceTypeIfEmpty(ServiceTypeElasticsearch),
+				service.CustomizeDiffCheckPlan(ServiceTypeElasticsearch),
+				service.CustomizeDiffEstimatedMonthlyCost(ServiceTypeElasticsearch),
 				customdiff.IfValueChange("disk_space",
 					service.DiskSpaceShouldNotBeEmpty,
 					service.CustomizeDiffCheckDiskSpace),
@@ -49,6 +52,11 @@ func resourceElasticsearch() *schema.Resource {
 			customdiff.IfValueChange("service_integrations",
 				service.ServiceIntegrationShouldNotBeEmpty,
 				service.CustomizeDiffServiceIntegrationAfterCreation),
+			customizeDiffUserConfigJSON("service", ServiceTypeElasticsearch),
+			customizeDiffUserConfigCreateOnly("service", ServiceTypeElasticsearch),
+			service.CustomizeDiffCheckVersionUpgrade(ServiceTypeElasticsearch),
+			customizeDiffServiceCloudName,
+			customizeDiffServiceStaticIPs(ServiceTypeElasticsearch),
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
@@ -59,6 +67,8 @@ func resourceElasticsearch() *schema.Resource {
 		},
 
 		Schema:             elasticsearchSchema(),
+		SchemaVersion:      1,
+		StateUpgraders:     userConfigStateUpgraders("service", elasticsearchSchema()),
 		DeprecationMessage: "Elasticsearch service is deprecated, please use aiven_opensearch",
 	}
 }
@@ -123,7 +123,7 @@ func testAccElasticsearchACLRuleResourceImport(project, service, user, index, pe
 }
 
 func testAccCheckAivenElasticsearchACLRuleResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each OS ACL is destroyed
 	for _, rs := range s.RootModule().Resources {
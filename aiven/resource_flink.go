@@ -33,6 +33,7 @@ func aivenFlinkSchema() map[string]*schema.Schema {
 		},
 	}
 	aivenFlinkSchema[ServiceTypeFlink+"_user_config"] = generateServiceUserConfiguration(ServiceTypeFlink)
+	aivenFlinkSchema[ServiceTypeFlink+"_user_config_json"] = generateServiceUserConfigurationJSON(ServiceTypeFlink)
 
 	return aivenFlinkSchema
 }
@@ -47,6 +48,8 @@ func resourceFlink() *schema.Resource {
 		CustomizeDiff: customdiff.All(
 			customdiff.Sequence(
 				service.SetServiceTypeIfEmpty(ServiceTypeFlink),
+				service.CustomizeDiffCheckPlan(ServiceTypeFlink),
+				service.CustomizeDiffEstimatedMonthlyCost(ServiceTypeFlink),
 				customdiff.IfValueChange("disk_space",
 					service.DiskSpaceShouldNotBeEmpty,
 					service.CustomizeDiffCheckDiskSpace),
@@ -54,6 +57,10 @@ func resourceFlink() *schema.Resource {
 			customdiff.IfValueChange("service_integrations",
 				service.ServiceIntegrationShouldNotBeEmpty,
 				service.CustomizeDiffServiceIntegrationAfterCreation),
+			customizeDiffUserConfigJSON("service", ServiceTypeFlink),
+			customizeDiffUserConfigCreateOnly("service", ServiceTypeFlink),
+			service.CustomizeDiffCheckVersionUpgrade(ServiceTypeFlink),
+			customizeDiffServiceCloudName,
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
@@ -63,6 +70,8 @@ func resourceFlink() *schema.Resource {
 			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
-		Schema: aivenFlinkSchema(),
+		Schema:         aivenFlinkSchema(),
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("service", aivenFlinkSchema()),
 	}
 }
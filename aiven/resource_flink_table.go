@@ -115,7 +115,7 @@ func resourceFlinkTable() *schema.Resource {
 }
 
 func resourceFlinkTableRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, tableId := splitResourceID3(d.Id())
 
@@ -147,7 +147,7 @@ func resourceFlinkTableRead(_ context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceFlinkTableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -190,7 +190,7 @@ func resourceFlinkTableCreate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceFlinkTableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, tableId := splitResourceID3(d.Id())
 
@@ -451,7 +451,7 @@ func TestAccAiven_flink(t *testing.T) {
 }
 
 func testAccCheckAivenFlinkJobsAndTableResourcesDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each job and table is destroyed
 	for _, rs := range s.RootModule().Resources {
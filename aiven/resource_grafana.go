@@ -21,6 +21,7 @@ func grafanaSchema() map[string]*schema.Schema {
 		},
 	}
 	s[ServiceTypeGrafana+"_user_config"] = generateServiceUserConfiguration(ServiceTypeGrafana)
+	s[ServiceTypeGrafana+"_user_config_json"] = generateServiceUserConfigurationJSON(ServiceTypeGrafana)
 
 	return s
 }
@@ -35,6 +36,8 @@ func resourceGrafana() *schema.Resource {
 		CustomizeDiff: customdiff.All(
 			customdiff.Sequence(
 				service.SetServiceTypeIfEmpty(ServiceTypeGrafana),
+				service.CustomizeDiffCheckPlan(ServiceTypeGrafana),
+				service.CustomizeDiffEstimatedMonthlyCost(ServiceTypeGrafana),
 				customdiff.IfValueChange("disk_space",
 					service.DiskSpaceShouldNotBeEmpty,
 					service.CustomizeDiffCheckDiskSpace),
@@ -42,6 +45,11 @@ func resourceGrafana() *schema.Resource {
 			customdiff.IfValueChange("service_integrations",
 				service.ServiceIntegrationShouldNotBeEmpty,
 				service.CustomizeDiffServiceIntegrationAfterCreation),
+			customizeDiffUserConfigJSON("service", ServiceTypeGrafana),
+			customizeDiffUserConfigCreateOnly("service", ServiceTypeGrafana),
+			service.CustomizeDiffCheckVersionUpgrade(ServiceTypeGrafana),
+			customizeDiffServiceCloudName,
+			customizeDiffServiceStaticIPs(ServiceTypeGrafana),
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
@@ -51,6 +59,8 @@ func resourceGrafana() *schema.Resource {
 			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
-		Schema: grafanaSchema(),
+		Schema:         grafanaSchema(),
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("service", grafanaSchema()),
 	}
 }
@@ -27,6 +27,8 @@ func influxDBSchema() map[string]*schema.Schema {
 		},
 	}
 	s[ServiceTypeInfluxDB+"_user_config"] = generateServiceUserConfiguration(ServiceTypeInfluxDB)
+	s[ServiceTypeInfluxDB+"_user_config_json"] = generateServiceUserConfigurationJSON(ServiceTypeInfluxDB)
+	s["restore"] = serviceRestoreSchema(ServiceTypeInfluxDB)
 
 	return s
 }
@@ -41,6 +43,8 @@ func resourceInfluxDB() *schema.Resource {
 		CustomizeDiff: customdiff.All(
 			customdiff.Sequence(
 				service.SetServiceTypeIfEmpty(ServiceTypeInfluxDB),
+				service.CustomizeDiffCheckPlan(ServiceTypeInfluxDB),
+				service.CustomizeDiffEstimatedMonthlyCost(ServiceTypeInfluxDB),
 				customdiff.IfValueChange("disk_space",
 					service.DiskSpaceShouldNotBeEmpty,
 					service.CustomizeDiffCheckDiskSpace),
@@ -48,6 +52,12 @@ func resourceInfluxDB() *schema.Resource {
 			customdiff.IfValueChange("service_integrations",
 				service.ServiceIntegrationShouldNotBeEmpty,
 				service.CustomizeDiffServiceIntegrationAfterCreation),
+			customizeDiffUserConfigJSON("service", ServiceTypeInfluxDB),
+			customizeDiffUserConfigCreateOnly("service", ServiceTypeInfluxDB),
+			service.CustomizeDiffCheckVersionUpgrade(ServiceTypeInfluxDB),
+			customizeDiffServiceCloudName,
+			customizeDiffServiceStaticIPs(ServiceTypeInfluxDB),
+			customizeDiffServiceRestore(ServiceTypeInfluxDB),
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
@@ -57,6 +67,8 @@ func resourceInfluxDB() *schema.Resource {
 			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
-		Schema: influxDBSchema(),
+		Schema:         influxDBSchema(),
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("service", influxDBSchema()),
 	}
 }
@@ -75,6 +75,7 @@ func aivenKafkaSchema() map[string]*schema.Schema {
 		},
 	}
 	aivenKafkaSchema[ServiceTypeKafka+"_user_config"] = generateServiceUserConfiguration(ServiceTypeKafka)
+	aivenKafkaSchema[ServiceTypeKafka+"_user_config_json"] = generateServiceUserConfigurationJSON(ServiceTypeKafka)
 
 	return aivenKafkaSchema
 }
@@ -94,10 +95,14 @@ func resourceKafka() *schema.Resource {
 			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
-		Schema: aivenKafkaSchema(),
+		Schema:         aivenKafkaSchema(),
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("service", aivenKafkaSchema()),
 		CustomizeDiff: customdiff.All(
 			customdiff.Sequence(
 				service.SetServiceTypeIfEmpty(ServiceTypeKafka),
+				service.CustomizeDiffCheckPlan(ServiceTypeKafka),
+				service.CustomizeDiffEstimatedMonthlyCost(ServiceTypeKafka),
 				customdiff.IfValueChange("disk_space",
 					service.DiskSpaceShouldNotBeEmpty,
 					service.CustomizeDiffCheckDiskSpace),
@@ -105,12 +110,17 @@ func resourceKafka() *schema.Resource {
 			customdiff.IfValueChange("service_integrations",
 				service.ServiceIntegrationShouldNotBeEmpty,
 				service.CustomizeDiffServiceIntegrationAfterCreation),
+			customizeDiffUserConfigJSON("service", ServiceTypeKafka),
+			customizeDiffUserConfigCreateOnly("service", ServiceTypeKafka),
+			service.CustomizeDiffCheckVersionUpgrade(ServiceTypeKafka),
+			customizeDiffServiceCloudName,
+			customizeDiffServiceStaticIPs(ServiceTypeKafka),
 
 			// if a kafka_version is >= 3.0 then this schema field is not applicable
 			customdiff.ComputedIf("karapace", func(ctx context.Context, d *schema.ResourceDiff, m interface{}) bool {
 				project := d.Get("project").(string)
 				serviceName := d.Get("service_name").(string)
-				client := m.(*aiven.Client)
+				client := m.(*providerMeta).client
 
 				kafka, err := client.Services.Get(project, serviceName)
 				if err != nil {
@@ -138,7 +148,7 @@ func resourceKafkaCreate(ctx context.Context, d *schema.ResourceData, m interfac
 
 	// if default_acl=false delete default wildcard Kafka ACL that is automatically created
 	if !d.Get("default_acl").(bool) {
-		client := m.(*aiven.Client)
+		client := m.(*providerMeta).client
 		project := d.Get("project").(string)
 		serviceName := d.Get("service_name").(string)
 
@@ -163,7 +173,7 @@ func resourceKafkaCreate(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceKafkaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	kafka, err := client.Services.Get(splitResourceID2(d.Id()))
 	if err := resourceReadHandleNotFound(err, d); err != nil {
@@ -21,6 +21,7 @@ func aivenKafkaConnectSchema() map[string]*schema.Schema {
 		},
 	}
 	kafkaConnectSchema[ServiceTypeKafkaConnect+"_user_config"] = generateServiceUserConfiguration(ServiceTypeKafkaConnect)
+	kafkaConnectSchema[ServiceTypeKafkaConnect+"_user_config_json"] = generateServiceUserConfigurationJSON(ServiceTypeKafkaConnect)
 
 	return kafkaConnectSchema
 }
@@ -35,6 +36,8 @@ func resourceKafkaConnect() *schema.Resource {
 		CustomizeDiff: customdiff.All(
 			customdiff.Sequence(
 				service.SetServiceTypeIfEmpty(ServiceTypeKafkaConnect),
+				service.CustomizeDiffCheckPlan(ServiceTypeKafkaConnect),
+				service.CustomizeDiffEstimatedMonthlyCost(ServiceTypeKafkaConnect),
 				customdiff.IfValueChange("disk_space",
 					service.DiskSpaceShouldNotBeEmpty,
 					service.CustomizeDiffCheckDiskSpace),
@@ -42,6 +45,11 @@ func resourceKafkaConnect() *schema.Resource {
 			customdiff.IfValueChange("service_integrations",
 				service.ServiceIntegrationShouldNotBeEmpty,
 				service.CustomizeDiffServiceIntegrationAfterCreation),
+			customizeDiffUserConfigJSON("service", ServiceTypeKafkaConnect),
+			customizeDiffUserConfigCreateOnly("service", ServiceTypeKafkaConnect),
+			service.CustomizeDiffCheckVersionUpgrade(ServiceTypeKafkaConnect),
+			customizeDiffServiceCloudName,
+			customizeDiffServiceStaticIPs(ServiceTypeKafkaConnect),
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
@@ -51,6 +59,8 @@ func resourceKafkaConnect() *schema.Resource {
 			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
-		Schema: aivenKafkaConnectSchema(),
+		Schema:         aivenKafkaConnectSchema(),
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("service", aivenKafkaConnectSchema()),
 	}
 }
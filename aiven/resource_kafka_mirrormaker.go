@@ -22,6 +22,7 @@ func aivenKafkaMirrormakerSchema() map[string]*schema.Schema {
 	}
 	kafkaMMSchema[ServiceTypeKafkaMirrormaker+"_user_config"] =
 		generateServiceUserConfiguration(ServiceTypeKafkaMirrormaker)
+	kafkaMMSchema[ServiceTypeKafkaMirrormaker+"_user_config_json"] = generateServiceUserConfigurationJSON(ServiceTypeKafkaMirrormaker)
 
 	return kafkaMMSchema
 }
@@ -36,6 +37,8 @@ func resourceKafkaMirrormaker() *schema.Resource {
 		CustomizeDiff: customdiff.All(
 			customdiff.Sequence(
 				service.SetServiceTypeIfEmpty(ServiceTypeKafkaMirrormaker),
+				service.CustomizeDiffCheckPlan(ServiceTypeKafkaMirrormaker),
+				service.CustomizeDiffEstimatedMonthlyCost(ServiceTypeKafkaMirrormaker),
 				customdiff.IfValueChange("disk_space",
 					service.DiskSpaceShouldNotBeEmpty,
 					service.CustomizeDiffCheckDiskSpace),
@@ -43,6 +46,11 @@ func resourceKafkaMirrormaker() *schema.Resource {
 			customdiff.IfValueChange("service_integrations",
 				service.ServiceIntegrationShouldNotBeEmpty,
 				service.CustomizeDiffServiceIntegrationAfterCreation),
+			customizeDiffUserConfigJSON("service", ServiceTypeKafkaMirrormaker),
+			customizeDiffUserConfigCreateOnly("service", ServiceTypeKafkaMirrormaker),
+			service.CustomizeDiffCheckVersionUpgrade(ServiceTypeKafkaMirrormaker),
+			customizeDiffServiceCloudName,
+			customizeDiffServiceStaticIPs(ServiceTypeKafkaMirrormaker),
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
@@ -52,6 +60,8 @@ func resourceKafkaMirrormaker() *schema.Resource {
 			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
-		Schema: aivenKafkaMirrormakerSchema(),
+		Schema:         aivenKafkaMirrormakerSchema(),
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("service", aivenKafkaMirrormakerSchema()),
 	}
 }
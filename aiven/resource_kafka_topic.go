@@ -6,16 +6,31 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// kafkaTopicMessageFormatVersionRegExp matches the Kafka versions of the message format, such as 2.8
+// and 0.10.0-IV1
+const kafkaTopicMessageFormatVersionRegExp = `^[0-9]+\.[0-9]+(\.[0-9]+)?(-IV[0-9]+)?$`
+
+var (
+	// kafkaTopicCleanupPolicies are the values of the cleanup policy of the Kafka topics
+	kafkaTopicCleanupPolicies = []string{"delete", "compact", "compact,delete"}
+
+	kafkaTopicCompressionTypes      = []string{"snappy", "gzip", "lz4", "producer", "uncompressed", "zstd"}
+	kafkaTopicMessageTimestampTypes = []string{"CreateTime", "LogAppendTime"}
+)
+
 var aivenKafkaTopicSchema = map[string]*schema.Schema{
 	"project":      commonSchemaProjectReference,
 	"service_name": commonSchemaServiceNameReference,
@@ -29,12 +44,12 @@ var aivenKafkaTopicSchema = map[string]*schema.Schema{
 	"partitions": {
 		Type:        schema.TypeInt,
 		Required:    true,
-		Description: "The number of partitions to create in the topic.",
+		Description: "The number of partitions to create in the topic. Partitions can be added to a topic but not removed, so the number cannot be decreased.",
 	},
 	"replication": {
 		Type:        schema.TypeInt,
 		Required:    true,
-		Description: "The replication factor for the topic.",
+		Description: "The replication factor for the topic. It cannot be greater than the number of nodes of the plan of the Kafka service, nor less than `config.min_insync_replicas`.",
 	},
 	"retention_bytes": {
 		Type:             schema.TypeInt,
@@ -102,60 +117,69 @@ var aivenKafkaTopicSchema = map[string]*schema.Schema{
 			Schema: map[string]*schema.Schema{
 				"cleanup_policy": {
 					Type:             schema.TypeString,
-					Description:      "cleanup.policy value",
+					Description:      complex("cleanup.policy value.").possibleValues(stringSliceToInterfaceSlice(kafkaTopicCleanupPolicies)...).build(),
 					Optional:         true,
+					ValidateFunc:     validation.StringInSlice(kafkaTopicCleanupPolicies, false),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"compression_type": {
 					Type:             schema.TypeString,
-					Description:      "compression.type value",
+					Description:      complex("compression.type value.").possibleValues(stringSliceToInterfaceSlice(kafkaTopicCompressionTypes)...).build(),
 					Optional:         true,
+					ValidateFunc:     validation.StringInSlice(kafkaTopicCompressionTypes, false),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"delete_retention_ms": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeInt,
 					Description:      "delete.retention.ms value",
 					Optional:         true,
+					ValidateFunc:     validation.IntAtLeast(0),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"file_delete_delay_ms": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeInt,
 					Description:      "file.delete.delay.ms value",
 					Optional:         true,
+					ValidateFunc:     validation.IntAtLeast(0),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"flush_messages": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeInt,
 					Description:      "flush.messages value",
 					Optional:         true,
+					ValidateFunc:     validation.IntAtLeast(1),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"flush_ms": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeInt,
 					Description:      "flush.ms value",
 					Optional:         true,
+					ValidateFunc:     validation.IntAtLeast(0),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"index_interval_bytes": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeInt,
 					Description:      "index.interval.bytes value",
 					Optional:         true,
+					ValidateFunc:     validation.IntAtLeast(0),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"max_compaction_lag_ms": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeInt,
 					Description:      "max.compaction.lag.ms value",
 					Optional:         true,
+					ValidateFunc:     validation.IntAtLeast(1),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"max_message_bytes": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeInt,
 					Description:      "max.message.bytes value",
 					Optional:         true,
+					ValidateFunc:     validation.IntAtLeast(0),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"message_downconversion_enable": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeBool,
 					Description:      "message.downconversion.enable value",
 					Optional:         true,
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
@@ -164,82 +188,94 @@ var aivenKafkaTopicSchema = map[string]*schema.Schema{
 					Type:             schema.TypeString,
 					Description:      "message.format.version value",
 					Optional:         true,
+					ValidateFunc:     validation.StringMatch(regexp.MustCompile(kafkaTopicMessageFormatVersionRegExp), "should be a Kafka version, such as 2.8 or 2.8-IV1"),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"message_timestamp_difference_max_ms": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeInt,
 					Description:      "message.timestamp.difference.max.ms value",
 					Optional:         true,
+					ValidateFunc:     validation.IntAtLeast(0),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"message_timestamp_type": {
 					Type:             schema.TypeString,
-					Description:      "message.timestamp.type value",
+					Description:      complex("message.timestamp.type value.").possibleValues(stringSliceToInterfaceSlice(kafkaTopicMessageTimestampTypes)...).build(),
 					Optional:         true,
+					ValidateFunc:     validation.StringInSlice(kafkaTopicMessageTimestampTypes, false),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"min_cleanable_dirty_ratio": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeFloat,
 					Description:      "min.cleanable.dirty.ratio value",
 					Optional:         true,
+					ValidateFunc:     validation.FloatBetween(0, 1),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"min_compaction_lag_ms": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeInt,
 					Description:      "min.compaction.lag.ms value",
 					Optional:         true,
+					ValidateFunc:     validation.IntAtLeast(0),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"min_insync_replicas": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeInt,
 					Description:      "min.insync.replicas value",
 					Optional:         true,
+					ValidateFunc:     validation.IntAtLeast(1),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"preallocate": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeBool,
 					Description:      "preallocate value",
 					Optional:         true,
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"retention_bytes": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeInt,
 					Description:      "retention.bytes value",
 					Optional:         true,
+					ValidateFunc:     validation.IntAtLeast(-1),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"retention_ms": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeInt,
 					Description:      "retention.ms value",
 					Optional:         true,
+					ValidateFunc:     validation.IntAtLeast(-1),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"segment_bytes": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeInt,
 					Description:      "segment.bytes value",
 					Optional:         true,
+					ValidateFunc:     validation.IntAtLeast(14),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"segment_index_bytes": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeInt,
 					Description:      "segment.index.bytes value",
 					Optional:         true,
+					ValidateFunc:     validation.IntAtLeast(4),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"segment_jitter_ms": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeInt,
 					Description:      "segment.jitter.ms value",
 					Optional:         true,
+					ValidateFunc:     validation.IntAtLeast(0),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"segment_ms": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeInt,
 					Description:      "segment.ms value",
 					Optional:         true,
+					ValidateFunc:     validation.IntAtLeast(1),
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 				},
 				"unclean_leader_election_enable": {
-					Type:             schema.TypeString,
+					Type:             schema.TypeBool,
 					Description:      "unclean.leader.election.enable value",
 					Optional:         true,
 					DiffSuppressFunc: emptyObjectDiffSuppressFunc,
@@ -264,7 +300,14 @@ func resourceKafkaTopic() *schema.Resource {
 			Read:   schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(2 * time.Minute),
 		},
-		Schema: aivenKafkaTopicSchema,
+		CustomizeDiff: customdiff.All(
+			customizeDiffKafkaTopicPartitions,
+			customizeDiffKafkaTopicMinInsyncReplicas,
+			customizeDiffKafkaTopicReplication,
+		),
+		Schema:         aivenKafkaTopicSchema,
+		SchemaVersion:  1,
+		StateUpgraders: kafkaTopicStateUpgraders(),
 	}
 }
 
@@ -288,7 +331,7 @@ func resourceKafkaTopicCreate(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	w := &KafkaTopicCreateWaiter{
-		Client:        m.(*aiven.Client),
+		Client:        m.(*providerMeta).client,
 		Project:       project,
 		ServiceName:   serviceName,
 		CreateRequest: createRequest,
@@ -300,6 +343,7 @@ func resourceKafkaTopicCreate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
+	m.(*providerMeta).topicCache.DeleteByTopicName(project, serviceName, topicName)
 	d.SetId(buildResourceID(project, serviceName, topicName))
 
 	// We do not call a Kafka Topic read here to speed up the performance.
@@ -333,33 +377,89 @@ func getKafkaTopicConfig(d *schema.ResourceData) aiven.KafkaTopicConfig {
 	}
 
 	configRaw := d.Get("config").([]interface{})[0].(map[string]interface{})
+	c := kafkaTopicConfig{
+		values:    configRaw,
+		rawConfig: rawConfigValue(resourceRawConfig(d), "config.0"),
+	}
 
 	return aiven.KafkaTopicConfig{
 		CleanupPolicy:                   configRaw["cleanup_policy"].(string),
 		CompressionType:                 configRaw["compression_type"].(string),
-		DeleteRetentionMs:               parseOptionalStringToInt64(configRaw["delete_retention_ms"]),
-		FileDeleteDelayMs:               parseOptionalStringToInt64(configRaw["file_delete_delay_ms"]),
-		FlushMessages:                   parseOptionalStringToInt64(configRaw["flush_messages"]),
-		FlushMs:                         parseOptionalStringToInt64(configRaw["flush_ms"]),
-		IndexIntervalBytes:              parseOptionalStringToInt64(configRaw["index_interval_bytes"]),
-		MaxCompactionLagMs:              parseOptionalStringToInt64(configRaw["max_compaction_lag_ms"]),
-		MaxMessageBytes:                 parseOptionalStringToInt64(configRaw["max_message_bytes"]),
-		MessageDownconversionEnable:     parseOptionalStringToBool(configRaw["message_downconversion_enable"]),
+		DeleteRetentionMs:               c.int64("delete_retention_ms"),
+		FileDeleteDelayMs:               c.int64("file_delete_delay_ms"),
+		FlushMessages:                   c.int64("flush_messages"),
+		FlushMs:                         c.int64("flush_ms"),
+		IndexIntervalBytes:              c.int64("index_interval_bytes"),
+		MaxCompactionLagMs:              c.int64("max_compaction_lag_ms"),
+		MaxMessageBytes:                 c.int64("max_message_bytes"),
+		MessageDownconversionEnable:     c.bool("message_downconversion_enable"),
 		MessageFormatVersion:            configRaw["message_format_version"].(string),
-		MessageTimestampDifferenceMaxMs: parseOptionalStringToInt64(configRaw["message_timestamp_difference_max_ms"]),
+		MessageTimestampDifferenceMaxMs: c.int64("message_timestamp_difference_max_ms"),
 		MessageTimestampType:            configRaw["message_timestamp_type"].(string),
-		MinCleanableDirtyRatio:          parseOptionalStringToFloat64(configRaw["min_cleanable_dirty_ratio"]),
-		MinCompactionLagMs:              parseOptionalStringToInt64(configRaw["min_compaction_lag_ms"]),
-		MinInsyncReplicas:               parseOptionalStringToInt64(configRaw["min_insync_replicas"]),
-		Preallocate:                     parseOptionalStringToBool(configRaw["preallocate"]),
-		RetentionBytes:                  parseOptionalStringToInt64(configRaw["retention_bytes"]),
-		RetentionMs:                     parseOptionalStringToInt64(configRaw["retention_ms"]),
-		SegmentBytes:                    parseOptionalStringToInt64(configRaw["segment_bytes"]),
-		SegmentIndexBytes:               parseOptionalStringToInt64(configRaw["segment_index_bytes"]),
-		SegmentJitterMs:                 parseOptionalStringToInt64(configRaw["segment_jitter_ms"]),
-		SegmentMs:                       parseOptionalStringToInt64(configRaw["segment_ms"]),
-		UncleanLeaderElectionEnable:     parseOptionalStringToBool(configRaw["unclean_leader_election_enable"]),
+		MinCleanableDirtyRatio:          c.float64("min_cleanable_dirty_ratio"),
+		MinCompactionLagMs:              c.int64("min_compaction_lag_ms"),
+		MinInsyncReplicas:               c.int64("min_insync_replicas"),
+		Preallocate:                     c.bool("preallocate"),
+		RetentionBytes:                  c.int64("retention_bytes"),
+		RetentionMs:                     c.int64("retention_ms"),
+		SegmentBytes:                    c.int64("segment_bytes"),
+		SegmentIndexBytes:               c.int64("segment_index_bytes"),
+		SegmentJitterMs:                 c.int64("segment_jitter_ms"),
+		SegmentMs:                       c.int64("segment_ms"),
+		UncleanLeaderElectionEnable:     c.bool("unclean_leader_election_enable"),
+	}
+}
+
+// kafkaTopicConfig reads the typed options of the config block, options which are not set have a
+// zero value, the raw configuration tells those apart from zero values which are set explicitly
+type kafkaTopicConfig struct {
+	values    map[string]interface{}
+	rawConfig cty.Value
+}
+
+func (c kafkaTopicConfig) isSet(k string) bool {
+	v, ok := c.values[k]
+	if !ok {
+		return false
+	}
+
+	// without the configuration only the values which differ from the zero value are set
+	if raw := rawConfigAttribute(c.rawConfig, k); raw.IsKnown() {
+		return !raw.IsNull()
+	}
+	switch v := v.(type) {
+	case int:
+		return v != 0
+	case float64:
+		return v != 0
+	case bool:
+		return v
 	}
+	return false
+}
+
+func (c kafkaTopicConfig) int64(k string) *int64 {
+	if !c.isSet(k) {
+		return nil
+	}
+	v := int64(c.values[k].(int))
+	return &v
+}
+
+func (c kafkaTopicConfig) float64(k string) *float64 {
+	if !c.isSet(k) {
+		return nil
+	}
+	v := c.values[k].(float64)
+	return &v
+}
+
+func (c kafkaTopicConfig) bool(k string) *bool {
+	if !c.isSet(k) {
+		return nil
+	}
+	v := c.values[k].(bool)
+	return &v
 }
 
 func resourceKafkaTopicRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -443,7 +543,7 @@ func getTopic(ctx context.Context, d *schema.ResourceData, m interface{}, ignore
 	project, serviceName, topicName := splitResourceID3(d.Id())
 
 	w := &KafkaTopicAvailabilityWaiter{
-		Client:      m.(*aiven.Client),
+		Cache:       m.(*providerMeta).topicCache,
 		Project:     project,
 		ServiceName: serviceName,
 		TopicName:   topicName,
@@ -460,7 +560,7 @@ func getTopic(ctx context.Context, d *schema.ResourceData, m interface{}, ignore
 }
 
 func resourceKafkaTopicUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	partitions := d.Get("partitions").(int)
 	projectName, serviceName, topicName := splitResourceID3(d.Id())
@@ -482,11 +582,13 @@ func resourceKafkaTopicUpdate(_ context.Context, d *schema.ResourceData, m inter
 		return diag.FromErr(err)
 	}
 
+	m.(*providerMeta).topicCache.DeleteByTopicName(projectName, serviceName, topicName)
+
 	return nil
 }
 
 func resourceKafkaTopicDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, topicName := splitResourceID3(d.Id())
 
@@ -507,6 +609,8 @@ func resourceKafkaTopicDelete(ctx context.Context, d *schema.ResourceData, m int
 		return diag.Errorf("error waiting for Aiven Kafka Topic to be DELETED: %s", err)
 	}
 
+	m.(*providerMeta).topicCache.DeleteByTopicName(projectName, serviceName, topicName)
+
 	return nil
 }
 
@@ -526,30 +630,30 @@ func resourceKafkaTopicState(ctx context.Context, d *schema.ResourceData, m inte
 func flattenKafkaTopicConfig(t aiven.KafkaTopic) []map[string]interface{} {
 	return []map[string]interface{}{
 		{
-			"cleanup_policy":                      toOptionalString(t.Config.CleanupPolicy.Value),
-			"compression_type":                    toOptionalString(t.Config.CompressionType.Value),
-			"delete_retention_ms":                 toOptionalString(t.Config.DeleteRetentionMs.Value),
-			"file_delete_delay_ms":                toOptionalString(t.Config.FileDeleteDelayMs.Value),
-			"flush_messages":                      toOptionalString(t.Config.FlushMessages.Value),
-			"flush_ms":                            toOptionalString(t.Config.FlushMs.Value),
-			"index_interval_bytes":                toOptionalString(t.Config.IndexIntervalBytes.Value),
-			"max_compaction_lag_ms":               toOptionalString(t.Config.MaxCompactionLagMs.Value),
-			"max_message_bytes":                   toOptionalString(t.Config.MaxMessageBytes.Value),
-			"message_downconversion_enable":       toOptionalString(t.Config.MessageDownconversionEnable.Value),
-			"message_format_version":              toOptionalString(t.Config.MessageFormatVersion.Value),
-			"message_timestamp_difference_max_ms": toOptionalString(t.Config.MessageTimestampDifferenceMaxMs.Value),
-			"message_timestamp_type":              toOptionalString(t.Config.MessageTimestampType.Value),
-			"min_cleanable_dirty_ratio":           toOptionalString(t.Config.MinCleanableDirtyRatio.Value),
-			"min_compaction_lag_ms":               toOptionalString(t.Config.MinCompactionLagMs.Value),
-			"min_insync_replicas":                 toOptionalString(t.Config.MinInsyncReplicas.Value),
-			"preallocate":                         toOptionalString(t.Config.Preallocate.Value),
-			"retention_bytes":                     toOptionalString(t.Config.RetentionBytes.Value),
-			"retention_ms":                        toOptionalString(t.Config.RetentionMs.Value),
-			"segment_bytes":                       toOptionalString(t.Config.SegmentBytes.Value),
-			"segment_index_bytes":                 toOptionalString(t.Config.SegmentIndexBytes.Value),
-			"segment_jitter_ms":                   toOptionalString(t.Config.SegmentJitterMs.Value),
-			"segment_ms":                          toOptionalString(t.Config.SegmentMs.Value),
-			"unclean_leader_election_enable":      toOptionalString(t.Config.UncleanLeaderElectionEnable.Value),
+			"cleanup_policy":                      t.Config.CleanupPolicy.Value,
+			"compression_type":                    t.Config.CompressionType.Value,
+			"delete_retention_ms":                 t.Config.DeleteRetentionMs.Value,
+			"file_delete_delay_ms":                t.Config.FileDeleteDelayMs.Value,
+			"flush_messages":                      t.Config.FlushMessages.Value,
+			"flush_ms":                            t.Config.FlushMs.Value,
+			"index_interval_bytes":                t.Config.IndexIntervalBytes.Value,
+			"max_compaction_lag_ms":               t.Config.MaxCompactionLagMs.Value,
+			"max_message_bytes":                   t.Config.MaxMessageBytes.Value,
+			"message_downconversion_enable":       t.Config.MessageDownconversionEnable.Value,
+			"message_format_version":              t.Config.MessageFormatVersion.Value,
+			"message_timestamp_difference_max_ms": t.Config.MessageTimestampDifferenceMaxMs.Value,
+			"message_timestamp_type":              t.Config.MessageTimestampType.Value,
+			"min_cleanable_dirty_ratio":           t.Config.MinCleanableDirtyRatio.Value,
+			"min_compaction_lag_ms":               t.Config.MinCompactionLagMs.Value,
+			"min_insync_replicas":                 t.Config.MinInsyncReplicas.Value,
+			"preallocate":                         t.Config.Preallocate.Value,
+			"retention_bytes":                     t.Config.RetentionBytes.Value,
+			"retention_ms":                        t.Config.RetentionMs.Value,
+			"segment_bytes":                       t.Config.SegmentBytes.Value,
+			"segment_index_bytes":                 t.Config.SegmentIndexBytes.Value,
+			"segment_jitter_ms":                   t.Config.SegmentJitterMs.Value,
+			"segment_ms":                          t.Config.SegmentMs.Value,
+			"unclean_leader_election_enable":      t.Config.UncleanLeaderElectionEnable.Value,
 		},
 	}
 }
@@ -10,9 +10,11 @@ import (
 	"testing"
 
 	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func init() {
@@ -342,7 +344,7 @@ func testAccCheckAivenKafkaTopicAttributes(n string) resource.TestCheckFunc {
 }
 
 func testAccCheckAivenKafkaTopicResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each kafka topic is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -399,3 +401,57 @@ func TestPartitions(t *testing.T) {
 		})
 	}
 }
+
+func Test_kafkaTopicConfigValidation(t *testing.T) {
+	r := resourceKafkaTopic()
+	validate := func(config map[string]interface{}) diag.Diagnostics {
+		return r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+			"project":      "project",
+			"service_name": "service",
+			"topic_name":   "topic",
+			"partitions":   3,
+			"replication":  2,
+			"config":       []interface{}{config},
+		}))
+	}
+
+	assert.False(t, validate(map[string]interface{}{
+		"cleanup_policy":            "compact,delete",
+		"compression_type":          "zstd",
+		"retention_ms":              -1,
+		"segment_ms":                604800000,
+		"min_cleanable_dirty_ratio": 0.5,
+		"message_format_version":    "2.8-IV1",
+		"message_timestamp_type":    "LogAppendTime",
+		"preallocate":               true,
+	}).HasError())
+
+	for _, config := range []map[string]interface{}{
+		{"cleanup_policy": "delete,compact,foo"},
+		{"compression_type": "brotli"},
+		{"delete_retention_ms": -1},
+		{"retention_ms": -2},
+		{"segment_ms": 0},
+		{"min_cleanable_dirty_ratio": 1.5},
+		{"message_format_version": "latest"},
+		{"message_timestamp_type": "CreationTime"},
+		{"flush_ms": "every minute"},
+		{"preallocate": "yes"},
+	} {
+		assert.True(t, validate(config).HasError(), "config %v should be invalid", config)
+	}
+}
+
+func Test_flattenKafkaTopicConfig(t *testing.T) {
+	topic := aiven.KafkaTopic{}
+	topic.Config.CleanupPolicy.Value = "compact"
+	topic.Config.RetentionMs.Value = -1
+	topic.Config.MinCleanableDirtyRatio.Value = 0.5
+	topic.Config.UncleanLeaderElectionEnable.Value = true
+
+	config := flattenKafkaTopicConfig(topic)[0]
+	assert.Equal(t, "compact", config["cleanup_policy"])
+	assert.Equal(t, int64(-1), config["retention_ms"])
+	assert.Equal(t, 0.5, config["min_cleanable_dirty_ratio"])
+	assert.Equal(t, true, config["unclean_leader_election_enable"])
+}
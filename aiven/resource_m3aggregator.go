@@ -21,6 +21,7 @@ func aivenM3AggregatorSchema() map[string]*schema.Schema {
 		},
 	}
 	schemaM3[ServiceTypeM3Aggregator+"_user_config"] = generateServiceUserConfiguration(ServiceTypeM3Aggregator)
+	schemaM3[ServiceTypeM3Aggregator+"_user_config_json"] = generateServiceUserConfigurationJSON(ServiceTypeM3Aggregator)
 
 	return schemaM3
 }
@@ -34,6 +35,8 @@ func resourceM3Aggregator() *schema.Resource {
 		CustomizeDiff: customdiff.All(
 			customdiff.Sequence(
 				service.SetServiceTypeIfEmpty(ServiceTypeM3Aggregator),
+				service.CustomizeDiffCheckPlan(ServiceTypeM3Aggregator),
+				service.CustomizeDiffEstimatedMonthlyCost(ServiceTypeM3Aggregator),
 				customdiff.IfValueChange("disk_space",
 					service.DiskSpaceShouldNotBeEmpty,
 					service.CustomizeDiffCheckDiskSpace),
@@ -41,6 +44,11 @@ func resourceM3Aggregator() *schema.Resource {
 			customdiff.IfValueChange("service_integrations",
 				service.ServiceIntegrationShouldNotBeEmpty,
 				service.CustomizeDiffServiceIntegrationAfterCreation),
+			customizeDiffUserConfigJSON("service", ServiceTypeM3Aggregator),
+			customizeDiffUserConfigCreateOnly("service", ServiceTypeM3Aggregator),
+			service.CustomizeDiffCheckVersionUpgrade(ServiceTypeM3Aggregator),
+			customizeDiffServiceCloudName,
+			customizeDiffServiceStaticIPs(ServiceTypeM3Aggregator),
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
@@ -50,6 +58,8 @@ func resourceM3Aggregator() *schema.Resource {
 			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
-		Schema: aivenM3AggregatorSchema(),
+		Schema:         aivenM3AggregatorSchema(),
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("service", aivenM3AggregatorSchema()),
 	}
 }
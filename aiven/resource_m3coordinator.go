@@ -0,0 +1,64 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"time"
+
+	"github.com/aiven/terraform-provider-aiven/pkg/service"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func aivenM3CoordinatorSchema() map[string]*schema.Schema {
+	schemaM3 := serviceCommonSchema()
+	schemaM3[ServiceTypeM3Coordinator] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "M3 coordinator specific server provided values",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{},
+		},
+	}
+	schemaM3[ServiceTypeM3Coordinator+"_user_config"] = generateServiceUserConfiguration(ServiceTypeM3Coordinator)
+	schemaM3[ServiceTypeM3Coordinator+"_user_config_json"] = generateServiceUserConfigurationJSON(ServiceTypeM3Coordinator)
+
+	return schemaM3
+}
+func resourceM3Coordinator() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The M3 Coordinator resource allows the creation and management of Aiven M3 Coordinator services.",
+		CreateContext: resourceServiceCreateWrapper(ServiceTypeM3Coordinator),
+		ReadContext:   resourceServiceRead,
+		UpdateContext: resourceServiceUpdate,
+		DeleteContext: resourceServiceDelete,
+		CustomizeDiff: customdiff.All(
+			customdiff.Sequence(
+				service.SetServiceTypeIfEmpty(ServiceTypeM3Coordinator),
+				service.CustomizeDiffCheckPlan(ServiceTypeM3Coordinator),
+				service.CustomizeDiffEstimatedMonthlyCost(ServiceTypeM3Coordinator),
+				customdiff.IfValueChange("disk_space",
+					service.DiskSpaceShouldNotBeEmpty,
+					service.CustomizeDiffCheckDiskSpace),
+			),
+			customdiff.IfValueChange("service_integrations",
+				service.ServiceIntegrationShouldNotBeEmpty,
+				service.CustomizeDiffServiceIntegrationAfterCreation),
+			customizeDiffUserConfigJSON("service", ServiceTypeM3Coordinator),
+			customizeDiffUserConfigCreateOnly("service", ServiceTypeM3Coordinator),
+			service.CustomizeDiffCheckVersionUpgrade(ServiceTypeM3Coordinator),
+			customizeDiffServiceCloudName,
+			customizeDiffServiceStaticIPs(ServiceTypeM3Coordinator),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServiceState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema:         aivenM3CoordinatorSchema(),
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("service", aivenM3CoordinatorSchema()),
+	}
+}
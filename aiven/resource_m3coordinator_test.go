@@ -0,0 +1,84 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAiven_m3coordinator(t *testing.T) {
+	resourceName := "aiven_m3coordinator.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccM3CoordinatorResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAivenServiceCommonAttributes("data.aiven_m3coordinator.service"),
+					resource.TestCheckResourceAttr(resourceName, "service_name", fmt.Sprintf("test-acc-m3c-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "service_type", "m3coordinator"),
+					resource.TestCheckResourceAttr(resourceName, "cloud_name", "google-europe-west1"),
+					resource.TestCheckResourceAttr(resourceName, "maintenance_window_dow", "monday"),
+					resource.TestCheckResourceAttr(resourceName, "maintenance_window_time", "10:00:00"),
+					resource.TestCheckResourceAttr(resourceName, "termination_protection", "false"),
+					resource.TestCheckResourceAttr("aiven_service_integration.int-m3db-coord", "integration_type", "m3coordinator"),
+				),
+			},
+		},
+	})
+}
+
+func testAccM3CoordinatorResource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+		  project = "%s"
+		}
+		
+		resource "aiven_m3db" "foo" {
+		  project      = data.aiven_project.foo.project
+		  cloud_name   = "google-europe-west1"
+		  plan         = "startup-8"
+		  service_name = "test-acc-m3d-%s"
+		
+		  m3db_user_config {
+		    namespaces {
+		      name = "%s"
+		      type = "unaggregated"
+		    }
+		  }
+		}
+		
+		resource "aiven_m3coordinator" "bar" {
+		  project                 = data.aiven_project.foo.project
+		  cloud_name              = "google-europe-west1"
+		  plan                    = "startup-8"
+		  service_name            = "test-acc-m3c-%s"
+		  maintenance_window_dow  = "monday"
+		  maintenance_window_time = "10:00:00"
+		}
+		
+		resource "aiven_service_integration" "int-m3db-coord" {
+		  project                  = data.aiven_project.foo.project
+		  integration_type         = "m3coordinator"
+		  source_service_name      = aiven_m3db.foo.service_name
+		  destination_service_name = aiven_m3coordinator.bar.service_name
+		}
+		
+		data "aiven_m3coordinator" "service" {
+		  service_name = aiven_m3coordinator.bar.service_name
+		  project      = aiven_m3coordinator.bar.project
+		
+		  depends_on = [aiven_m3coordinator.bar]
+		}`,
+		os.Getenv("AIVEN_PROJECT_NAME"), name, name, name)
+}
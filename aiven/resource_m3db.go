@@ -21,6 +21,8 @@ func aivenM3DBSchema() map[string]*schema.Schema {
 		},
 	}
 	schemaM3[ServiceTypeM3+"_user_config"] = generateServiceUserConfiguration(ServiceTypeM3)
+	schemaM3[ServiceTypeM3+"_user_config_json"] = generateServiceUserConfigurationJSON(ServiceTypeM3)
+	schemaM3["restore"] = serviceRestoreSchema(ServiceTypeM3)
 
 	return schemaM3
 }
@@ -34,6 +36,8 @@ func resourceM3DB() *schema.Resource {
 		CustomizeDiff: customdiff.All(
 			customdiff.Sequence(
 				service.SetServiceTypeIfEmpty(ServiceTypeM3),
+				service.CustomizeDiffCheckPlan(ServiceTypeM3),
+				service.CustomizeDiffEstimatedMonthlyCost(ServiceTypeM3),
 				customdiff.IfValueChange("disk_space",
 					service.DiskSpaceShouldNotBeEmpty,
 					service.CustomizeDiffCheckDiskSpace),
@@ -41,6 +45,12 @@ func resourceM3DB() *schema.Resource {
 			customdiff.IfValueChange("service_integrations",
 				service.ServiceIntegrationShouldNotBeEmpty,
 				service.CustomizeDiffServiceIntegrationAfterCreation),
+			customizeDiffUserConfigJSON("service", ServiceTypeM3),
+			customizeDiffUserConfigCreateOnly("service", ServiceTypeM3),
+			service.CustomizeDiffCheckVersionUpgrade(ServiceTypeM3),
+			customizeDiffServiceCloudName,
+			customizeDiffServiceStaticIPs(ServiceTypeM3),
+			customizeDiffServiceRestore(ServiceTypeM3),
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
@@ -50,6 +60,8 @@ func resourceM3DB() *schema.Resource {
 			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
-		Schema: aivenM3DBSchema(),
+		Schema:         aivenM3DBSchema(),
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("service", aivenM3DBSchema()),
 	}
 }
@@ -21,6 +21,8 @@ func aivenMySQLSchema() map[string]*schema.Schema {
 		},
 	}
 	schemaMySQL[ServiceTypeMySQL+"_user_config"] = generateServiceUserConfiguration(ServiceTypeMySQL)
+	schemaMySQL[ServiceTypeMySQL+"_user_config_json"] = generateServiceUserConfigurationJSON(ServiceTypeMySQL)
+	schemaMySQL["restore"] = serviceRestoreSchema(ServiceTypeMySQL)
 
 	return schemaMySQL
 }
@@ -34,6 +36,8 @@ func resourceMySQL() *schema.Resource {
 		CustomizeDiff: customdiff.All(
 			customdiff.Sequence(
 				service.SetServiceTypeIfEmpty(ServiceTypeMySQL),
+				service.CustomizeDiffCheckPlan(ServiceTypeMySQL),
+				service.CustomizeDiffEstimatedMonthlyCost(ServiceTypeMySQL),
 				customdiff.IfValueChange("disk_space",
 					service.DiskSpaceShouldNotBeEmpty,
 					service.CustomizeDiffCheckDiskSpace),
@@ -41,6 +45,12 @@ func resourceMySQL() *schema.Resource {
 			customdiff.IfValueChange("service_integrations",
 				service.ServiceIntegrationShouldNotBeEmpty,
 				service.CustomizeDiffServiceIntegrationAfterCreation),
+			customizeDiffUserConfigJSON("service", ServiceTypeMySQL),
+			customizeDiffUserConfigCreateOnly("service", ServiceTypeMySQL),
+			service.CustomizeDiffCheckVersionUpgrade(ServiceTypeMySQL),
+			customizeDiffServiceCloudName,
+			customizeDiffServiceStaticIPs(ServiceTypeMySQL),
+			customizeDiffServiceRestore(ServiceTypeMySQL),
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
@@ -50,6 +60,8 @@ func resourceMySQL() *schema.Resource {
 			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
-		Schema: aivenMySQLSchema(),
+		Schema:         aivenMySQLSchema(),
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("service", aivenMySQLSchema()),
 	}
 }
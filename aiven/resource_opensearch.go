@@ -8,7 +8,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/aiven/terraform-provider-aiven/pkg/service"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -32,6 +31,8 @@ func opensearchSchema() map[string]*schema.Schema {
 		},
 	}
 	s[ServiceTypeOpensearch+"_user_config"] = generateServiceUserConfiguration(ServiceTypeOpensearch)
+	s[ServiceTypeOpensearch+"_user_config_json"] = generateServiceUserConfigurationJSON(ServiceTypeOpensearch)
+	s["restore"] = serviceRestoreSchema(ServiceTypeOpensearch)
 
 	return s
 }
@@ -45,9 +46,17 @@ func resourceOpensearch() *schema.Resource {
 		DeleteContext: resourceServiceDelete,
 		CustomizeDiff: customdiff.All(
 			service.SetServiceTypeIfEmpty(ServiceTypeOpensearch),
+			service.CustomizeDiffCheckPlan(ServiceTypeOpensearch),
+			service.CustomizeDiffEstimatedMonthlyCost(ServiceTypeOpensearch),
 			customdiff.IfValueChange("service_integrations",
 				service.ServiceIntegrationShouldNotBeEmpty,
 				service.CustomizeDiffServiceIntegrationAfterCreation),
+			customizeDiffUserConfigJSON("service", ServiceTypeOpensearch),
+			customizeDiffUserConfigCreateOnly("service", ServiceTypeOpensearch),
+			service.CustomizeDiffCheckVersionUpgrade(ServiceTypeOpensearch),
+			customizeDiffServiceCloudName,
+			customizeDiffServiceStaticIPs(ServiceTypeOpensearch),
+			customizeDiffServiceRestore(ServiceTypeOpensearch),
 			customdiff.IfValueChange("disk_space",
 				service.DiskSpaceShouldNotBeEmpty,
 				service.CustomizeDiffCheckDiskSpace),
@@ -60,12 +69,14 @@ func resourceOpensearch() *schema.Resource {
 			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
-		Schema: opensearchSchema(),
+		Schema:         opensearchSchema(),
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("service", opensearchSchema()),
 	}
 }
 
 func resourceElasticsearchState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if len(strings.Split(d.Id(), "/")) != 2 {
 		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>", d.Id())
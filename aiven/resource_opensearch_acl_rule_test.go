@@ -75,7 +75,7 @@ func testAccOpensearchACLRuleResource(name string) string {
 }
 
 func testAccCheckAivenOpensearchACLRuleResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each ES ACL is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -100,6 +100,7 @@ func TestAccAiven_pg(t *testing.T) {
 						resource.TestCheckResourceAttr(resourceName, "disk_space", "90GiB"),
 						resource.TestCheckResourceAttr(resourceName, "disk_space_used", "90GiB"),
 						resource.TestCheckResourceAttr(resourceName, "termination_protection", "false"),
+						testAccCheckAivenServiceEstimatedMonthlyCost(resourceName),
 					),
 				},
 				{
@@ -166,6 +167,77 @@ func TestAccAiven_pg(t *testing.T) {
 		})
 	})
 
+	t.Run("user config as JSON", func(tt *testing.T) {
+		rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+		resource.ParallelTest(tt, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(tt) },
+			ProviderFactories: testAccProviderFactories,
+			CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config:      testAccPGResourceUserConfigJSONConflict(rName),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(`"pg_user_config_json": conflicts with pg_user_config`),
+				},
+				{
+					Config:      testAccPGResourceUserConfigJSON(rName, `{ pg = { max_connections = 100 } }`),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(`unsupported option "max_connections"`),
+				},
+				{
+					Config: testAccPGResourceUserConfigJSON(rName, `{ ip_filter = ["10.0.0.0/8", "0.0.0.0/0"], pg = { idle_in_transaction_session_timeout = 900 } }`),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+						resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+						resource.TestCheckResourceAttr(resourceName, "pg_user_config_json",
+							`{"ip_filter":["10.0.0.0/8","0.0.0.0/0"],"pg":{"idle_in_transaction_session_timeout":900}}`),
+						resource.TestCheckResourceAttr(resourceName, "pg_user_config.#", "0"),
+						resource.TestCheckResourceAttrSet("data.aiven_pg.service", "pg_user_config_json"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("powering a service off and on", func(tt *testing.T) {
+		rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+		resource.ParallelTest(tt, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(tt) },
+			ProviderFactories: testAccProviderFactories,
+			CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccPGResourcePowered(rName, true),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+						resource.TestCheckResourceAttr(resourceName, "powered", "true"),
+						resource.TestCheckResourceAttrSet(resourceName, "service_uri"),
+						testAccCheckAivenServiceEstimatedMonthlyCost(resourceName),
+					),
+				},
+				{
+					Config: testAccPGResourcePowered(rName, false),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "state", "POWEROFF"),
+						resource.TestCheckResourceAttr(resourceName, "powered", "false"),
+						resource.TestCheckResourceAttrSet(resourceName, "service_uri"),
+						resource.TestCheckResourceAttr(resourceName, "estimated_monthly_cost_usd", "0"),
+						resource.TestCheckResourceAttr(resourceName, "termination_protection", "false"),
+					),
+				},
+				{
+					Config: testAccPGResourcePowered(rName, true),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+						resource.TestCheckResourceAttr(resourceName, "powered", "true"),
+					),
+				},
+			},
+		})
+	})
+
 	t.Run("changing plan of a service when disc size is not set", func(tt *testing.T) {
 		rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
 
@@ -317,3 +389,68 @@ func testAccPGResourcePlanChange(name, plan string) string {
 		}`,
 		os.Getenv("AIVEN_PROJECT_NAME"), plan, name)
 }
+
+func testAccPGResourceUserConfigJSON(name, userConfig string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+		  project = "%s"
+		}
+		
+		resource "aiven_pg" "bar" {
+		  project                 = data.aiven_project.foo.project
+		  cloud_name              = "google-europe-west1"
+		  plan                    = "startup-4"
+		  service_name            = "test-acc-sr-%s"
+		  maintenance_window_dow  = "monday"
+		  maintenance_window_time = "10:00:00"
+		  pg_user_config_json     = jsonencode(%s)
+		}
+		
+		data "aiven_pg" "service" {
+		  service_name = aiven_pg.bar.service_name
+		  project      = aiven_pg.bar.project
+		
+		  depends_on = [aiven_pg.bar]
+		}`,
+		os.Getenv("AIVEN_PROJECT_NAME"), name, userConfig)
+}
+
+func testAccPGResourceUserConfigJSONConflict(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+		  project = "%s"
+		}
+		
+		resource "aiven_pg" "bar" {
+		  project                 = data.aiven_project.foo.project
+		  cloud_name              = "google-europe-west1"
+		  plan                    = "startup-4"
+		  service_name            = "test-acc-sr-%s"
+		  maintenance_window_dow  = "monday"
+		  maintenance_window_time = "10:00:00"
+		  pg_user_config_json     = jsonencode({ pg_version = "13" })
+		
+		  pg_user_config {
+		    pg_version = "13"
+		  }
+		}`,
+		os.Getenv("AIVEN_PROJECT_NAME"), name)
+}
+
+func testAccPGResourcePowered(name string, powered bool) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+		  project = "%s"
+		}
+		
+		resource "aiven_pg" "bar" {
+		  project                 = data.aiven_project.foo.project
+		  cloud_name              = "google-europe-west1"
+		  plan                    = "startup-4"
+		  service_name            = "test-acc-sr-%s"
+		  maintenance_window_dow  = "monday"
+		  maintenance_window_time = "10:00:00"
+		  powered                 = %t
+		}`,
+		os.Getenv("AIVEN_PROJECT_NAME"), name, powered)
+}
@@ -10,6 +10,7 @@ import (
 	"regexp"
 
 	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/pkg/service"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -74,6 +75,11 @@ var aivenProjectSchema = map[string]*schema.Schema{
 		Computed:    true,
 		Description: "The current accumulated bill for this project in the current billing period.",
 	},
+	"estimated_monthly_cost_usd": {
+		Type:        schema.TypeFloat,
+		Computed:    true,
+		Description: "The sum of the estimated monthly costs of the services of the project that are powered on in USD, see the `estimated_monthly_cost_usd` attribute of the services.",
+	},
 	"payment_method": {
 		Type:        schema.TypeString,
 		Computed:    true,
@@ -155,7 +161,7 @@ func resourceProject() *schema.Resource {
 }
 
 func resourceProjectCreate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	cardID, err := getLongCardID(client, d.Get("card_id").(string))
 	if err != nil {
 		return diag.Errorf("Error getting long card id: %s", err)
@@ -287,7 +293,7 @@ func resourceProjectAssignToBillingGroup(
 }
 
 func resourceProjectRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, err := client.Projects.Get(d.Id())
 	if err != nil {
@@ -335,11 +341,11 @@ func resourceProjectRead(_ context.Context, d *schema.ResourceData, m interface{
 		}
 	}
 
-	return append(diags, setProjectTerraformProperties(d, client, project)...)
+	return append(diags, setProjectTerraformProperties(d, m, project)...)
 }
 
 func resourceProjectUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	cardID, err := getLongCardID(client, d.Get("card_id").(string))
 	if err != nil {
@@ -401,7 +407,7 @@ func resourceProjectUpdate(_ context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceProjectDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	err := client.Projects.Delete(d.Id())
 
@@ -426,7 +432,7 @@ func resourceProjectDelete(_ context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceProjectState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, err := client.Projects.Get(d.Id())
 	if err != nil {
@@ -437,7 +443,7 @@ func resourceProjectState(_ context.Context, d *schema.ResourceData, m interface
 		return nil, err
 	}
 
-	if d := setProjectTerraformProperties(d, client, project); d.HasError() {
+	if d := setProjectTerraformProperties(d, m, project); d.HasError() {
 		return nil, fmt.Errorf("cannot set project properties")
 	}
 
@@ -510,7 +516,9 @@ func contactEmailListForTerraform(d *schema.ResourceData, field string, contactE
 	return nil
 }
 
-func setProjectTerraformProperties(d *schema.ResourceData, client *aiven.Client, project *aiven.Project) diag.Diagnostics {
+func setProjectTerraformProperties(d *schema.ResourceData, m interface{}, project *aiven.Project) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
 	if err := d.Set("billing_address", project.BillingAddress); err != nil {
 		return diag.FromErr(err)
 	}
@@ -547,6 +555,11 @@ func setProjectTerraformProperties(d *schema.ResourceData, client *aiven.Client,
 	if err := d.Set("estimated_balance", project.EstimatedBalance); err != nil {
 		return diag.FromErr(err)
 	}
+	if cost, err := service.ProjectEstimatedMonthlyCostUSD(m, client, project.Name); err != nil {
+		log.Printf("[WARN] unable to estimate the monthly cost of project %s: %s", project.Name, err)
+	} else if err := d.Set("estimated_monthly_cost_usd", cost); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := d.Set("payment_method", project.PaymentMethod); err != nil {
 		return diag.FromErr(err)
 	}
@@ -81,6 +81,7 @@ func TestAccAivenProject_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "billing_currency"),
 					resource.TestCheckResourceAttrSet(resourceName, "ca_cert"),
 					resource.TestCheckResourceAttrSet(resourceName, "vat_id"),
+					resource.TestCheckResourceAttr(resourceName, "estimated_monthly_cost_usd", "0"),
 				),
 			},
 			{
@@ -218,7 +219,7 @@ func testAccCheckAivenProjectAttributes(n string, attributes ...string) resource
 }
 
 func testAccCheckAivenProjectResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each project is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -119,7 +119,7 @@ func testAccCheckAivenProjectVPCAttributes(n string) resource.TestCheckFunc {
 }
 
 func testAccCheckAivenProjectVPCResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each project VPC is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -21,6 +21,8 @@ func redisSchema() map[string]*schema.Schema {
 		},
 	}
 	s[ServiceTypeRedis+"_user_config"] = generateServiceUserConfiguration(ServiceTypeRedis)
+	s[ServiceTypeRedis+"_user_config_json"] = generateServiceUserConfigurationJSON(ServiceTypeRedis)
+	s["restore"] = serviceRestoreSchema(ServiceTypeRedis)
 
 	return s
 }
@@ -35,6 +37,8 @@ func resourceRedis() *schema.Resource {
 		CustomizeDiff: customdiff.All(
 			customdiff.Sequence(
 				service.SetServiceTypeIfEmpty(ServiceTypeRedis),
+				service.CustomizeDiffCheckPlan(ServiceTypeRedis),
+				service.CustomizeDiffEstimatedMonthlyCost(ServiceTypeRedis),
 				customdiff.IfValueChange("disk_space",
 					service.DiskSpaceShouldNotBeEmpty,
 					service.CustomizeDiffCheckDiskSpace),
@@ -42,6 +46,12 @@ func resourceRedis() *schema.Resource {
 			customdiff.IfValueChange("service_integrations",
 				service.ServiceIntegrationShouldNotBeEmpty,
 				service.CustomizeDiffServiceIntegrationAfterCreation),
+			customizeDiffUserConfigJSON("service", ServiceTypeRedis),
+			customizeDiffUserConfigCreateOnly("service", ServiceTypeRedis),
+			service.CustomizeDiffCheckVersionUpgrade(ServiceTypeRedis),
+			customizeDiffServiceCloudName,
+			customizeDiffServiceStaticIPs(ServiceTypeRedis),
+			customizeDiffServiceRestore(ServiceTypeRedis),
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
@@ -51,6 +61,8 @@ func resourceRedis() *schema.Resource {
 			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
-		Schema: redisSchema(),
+		Schema:         redisSchema(),
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("service", redisSchema()),
 	}
 }
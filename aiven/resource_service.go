@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
 	"github.com/aiven/terraform-provider-aiven/pkg/ipfilter"
 	"github.com/aiven/terraform-provider-aiven/pkg/service"
 	"github.com/docker/go-units"
@@ -33,6 +35,7 @@ const (
 	ServiceTypeKafkaMirrormaker = "kafka_mirrormaker"
 	ServiceTypeM3               = "m3db"
 	ServiceTypeM3Aggregator     = "m3aggregator"
+	ServiceTypeM3Coordinator    = "m3coordinator"
 	ServiceTypeFlink            = "flink"
 	ServiceTypeClickhouse       = "clickhouse"
 )
@@ -51,6 +54,7 @@ func availableServiceTypes() []string {
 		ServiceTypeKafkaMirrormaker,
 		ServiceTypeM3,
 		ServiceTypeM3Aggregator,
+		ServiceTypeM3Coordinator,
 		ServiceTypeOpensearch,
 		ServiceTypeFlink,
 		ServiceTypeClickhouse,
@@ -64,12 +68,12 @@ func serviceCommonSchema() map[string]*schema.Schema {
 		"cloud_name": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Description: "Defines where the cloud provider and region where the service is hosted in. This can be changed freely after service is created. Changing the value will trigger a potentially lengthy migration process for the service. Format is cloud provider name (`aws`, `azure`, `do` `google`, `upcloud`, etc.), dash, and the cloud provider specific region name. These are documented on each Cloud provider's own support articles, like [here for Google](https://cloud.google.com/compute/docs/regions-zones/) and [here for AWS](https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/Concepts.RegionsAndAvailabilityZones.html).",
+			Description: "Defines where the cloud provider and region where the service is hosted in. This can be changed freely after service is created. Changing the value will trigger a potentially lengthy migration process for the service. Format is cloud provider name (`aws`, `azure`, `do` `google`, `upcloud`, etc.), dash, and the cloud provider specific region name. These are documented on each Cloud provider's own support articles, like [here for Google](https://cloud.google.com/compute/docs/regions-zones/) and [here for AWS](https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/Concepts.RegionsAndAvailabilityZones.html). The clouds available to the project are listed by the `aiven_clouds` data source, the plan fails when the cloud is not available.",
 		},
 		"plan": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Description: "Defines what kind of computing resources are allocated for the service. It can be changed after creation, though there are some restrictions when going to a smaller plan such as the new plan must have sufficient amount of disk space to store all current data and switching to a plan with fewer nodes might not be supported. The basic plan names are `hobbyist`, `startup-x`, `business-x` and `premium-x` where `x` is (roughly) the amount of memory on each node (also other attributes like number of CPUs and amount of disk space varies but naming is based on memory). The available options can be seem from the [Aiven pricing page](https://aiven.io/pricing).",
+			Description: "Defines what kind of computing resources are allocated for the service. It can be changed after creation, though there are some restrictions when going to a smaller plan such as the new plan must have sufficient amount of disk space to store all current data and switching to a plan with fewer nodes might not be supported. The basic plan names are `hobbyist`, `startup-x`, `business-x` and `premium-x` where `x` is (roughly) the amount of memory on each node (also other attributes like number of CPUs and amount of disk space varies but naming is based on memory). The available options can be seem from the [Aiven pricing page](https://aiven.io/pricing) or the `aiven_service_plans` data source. The plan fails when the plan is not available for the service type in the cloud, when the new plan has fewer nodes or when the disk space would be less than the disk space the service uses.",
 		},
 		"service_name": {
 			Type:        schema.TypeString,
@@ -88,17 +92,19 @@ func serviceCommonSchema() map[string]*schema.Schema {
 			Description: "Specifies the VPC the service should run in. If the value is not set the service is not run inside a VPC. When set, the value should be given as a reference to set up dependencies correctly and the VPC must be in the same cloud and region as the service itself. Project can be freely moved to and from VPC after creation but doing so triggers migration to new servers so the operation can take significant amount of time to complete if the service has a lot of data.",
 		},
 		"maintenance_window_dow": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "Day of week when maintenance operations should be performed. One monday, tuesday, wednesday, etc.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Day of week when maintenance operations should be performed. One monday, tuesday, wednesday, etc.",
+			ValidateFunc: validateMaintenanceWindowDow,
 			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 				return new == ""
 			},
 		},
 		"maintenance_window_time": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "Time of day when maintenance operations should be performed. UTC time in HH:mm:ss format.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Time of day when maintenance operations should be performed. UTC time in HH:mm:ss format.",
+			ValidateFunc: validateMaintenanceWindowTime,
 			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 				return new == ""
 			},
@@ -108,17 +114,29 @@ func serviceCommonSchema() map[string]*schema.Schema {
 			Optional:    true,
 			Description: "Prevents the service from being deleted. It is recommended to set this to `true` for all production services to prevent unintentional service deletion. This does not shield against deleting databases or topics but for services with backups much of the content can at least be restored from backup in case accidental deletion is done.",
 		},
+		"powered": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Powers the service on or off. A powered off service is not billed, but services without backups, such as Kafka, lose all their data when they are powered off. The connection information of a powered off service is kept from the time it was last running. The default is true.",
+		},
 		"disk_space": {
 			Type:         schema.TypeString,
 			Optional:     true,
 			Description:  "The disk space of the service, possible values depend on the service type, the cloud provider and the project. Reducing will result in the service rebalancing.",
 			ValidateFunc: validateHumanByteSizeString,
 		},
+		"maintenance_updates": maintenanceUpdatesSchema(),
 		"disk_space_used": {
 			Type:        schema.TypeString,
 			Computed:    true,
 			Description: "Disk space that service is currently using",
 		},
+		"estimated_monthly_cost_usd": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "The estimated monthly cost of the service in USD, which is the base price of the plan in the cloud and the price of the disk space above `disk_space_default`, based on 730 hours a month. It is 0 when the service is powered off. The plan shows the cost before and after changes to the plan, cloud or disk space, and a warning reports the change when it is applied.",
+		},
 		"disk_space_default": {
 			Type:        schema.TypeString,
 			Computed:    true,
@@ -166,6 +184,19 @@ func serviceCommonSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Service state. One of `POWEROFF`, `REBALANCING`, `REBUILDING` or `RUNNING`.",
 		},
+		"replace_on_create_only_changes": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Description: "Replace the service instead of failing the plan when a user config option that can only be set " +
+				"when the service is created, such as `recovery_target_time`, is changed. The default is false.",
+		},
+		"wait_for_migration": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Description: "Wait until the migration of the service is finished when it is updated, instead of returning while " +
+				"the service is still being rebuilt after a plan, cloud or VPC change. Defaults to the " +
+				"`wait_for_migration` option of the provider.",
+		},
 		"service_integrations": {
 			Type:        schema.TypeList,
 			Optional:    true,
@@ -271,17 +302,19 @@ var aivenServiceSchema = map[string]*schema.Schema{
 		Description: "Identifier of the VPC the service should be in, if any",
 	},
 	"maintenance_window_dow": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Day of week when maintenance operations should be performed. One monday, tuesday, wednesday, etc.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "Day of week when maintenance operations should be performed. One monday, tuesday, wednesday, etc.",
+		ValidateFunc: validateMaintenanceWindowDow,
 		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 			return new == ""
 		},
 	},
 	"maintenance_window_time": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Time of day when maintenance operations should be performed. UTC time in HH:mm:ss format.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "Time of day when maintenance operations should be performed. UTC time in HH:mm:ss format.",
+		ValidateFunc: validateMaintenanceWindowTime,
 		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 			return new == ""
 		},
@@ -291,6 +324,12 @@ var aivenServiceSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Prevent service from being deleted. It is recommended to have this enabled for all services.",
 	},
+	"powered": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "Power the service on or off. Services without backups lose all their data when they are powered off.",
+	},
 	"disk_space": {
 		Type:             schema.TypeString,
 		Optional:         true,
@@ -298,11 +337,17 @@ var aivenServiceSchema = map[string]*schema.Schema{
 		DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 		ValidateFunc:     validateHumanByteSizeString,
 	},
+	"maintenance_updates": maintenanceUpdatesSchema(),
 	"disk_space_used": {
 		Type:        schema.TypeString,
 		Computed:    true,
 		Description: "Disk space that service is currently using",
 	},
+	"estimated_monthly_cost_usd": {
+		Type:        schema.TypeFloat,
+		Computed:    true,
+		Description: "The estimated monthly cost of the service in USD, which is the base price of the plan in the cloud and the price of the disk space above `disk_space_default`, based on 730 hours a month. It is 0 when the service is powered off. The plan shows the cost before and after changes to the plan, cloud or disk space, and a warning reports the change when it is applied.",
+	},
 	"disk_space_default": {
 		Type:        schema.TypeString,
 		Computed:    true,
@@ -329,6 +374,18 @@ var aivenServiceSchema = map[string]*schema.Schema{
 		Computed:    true,
 		Description: "Service hostname",
 	},
+	"replace_on_create_only_changes": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Description: "Replace the service instead of failing the plan when a user config option that can only be set " +
+			"when the service is created, such as `recovery_target_time`, is changed. The default is false.",
+	},
+	"wait_for_migration": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Description: "Wait until the migration of the service is finished when it is updated. Defaults to the " +
+			"`wait_for_migration` option of the provider.",
+	},
 	"service_integrations": {
 		Type:        schema.TypeList,
 		Optional:    true,
@@ -661,6 +718,12 @@ func resourceService() *schema.Resource {
 			customdiff.IfValueChange("service_integrations",
 				service.ServiceIntegrationShouldNotBeEmpty,
 				service.CustomizeDiffServiceIntegrationAfterCreation),
+			customizeDiffUserConfigCreateOnly("service", userConfigEntryTypes(aivenServiceSchema)...),
+			service.CustomizeDiffCheckVersionUpgrade(""),
+			customizeDiffServiceCloudName,
+			customizeDiffServiceStaticIPs(userConfigEntryTypes(aivenServiceSchema)...),
+			service.CustomizeDiffCheckPlan(""),
+			service.CustomizeDiffEstimatedMonthlyCost(""),
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
@@ -669,7 +732,9 @@ func resourceService() *schema.Resource {
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
-		Schema: aivenServiceSchema,
+		Schema:         aivenServiceSchema,
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("service", aivenServiceSchema),
 	}
 }
 
@@ -733,11 +798,25 @@ func resourceServiceCreateWrapper(serviceType string) schema.CreateContextFunc {
 	}
 }
 
+// serviceCostNotRefreshedSummary is the summary of the warning about an estimated monthly cost that
+// cannot be refreshed, the change of the cost is not summarized after such a refresh
+const serviceCostNotRefreshedSummary = "Estimated monthly cost not refreshed"
+
+// hasDiagnosticSummary checks if one of the diagnostics has the summary
+func hasDiagnosticSummary(diags diag.Diagnostics, summary string) bool {
+	for _, d := range diags {
+		if d.Summary == summary {
+			return true
+		}
+	}
+	return false
+}
+
 func resourceServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName := splitResourceID2(d.Id())
-	s, err := client.Services.Get(projectName, serviceName)
+	s, maintenance, err := aivenapi.GetServiceWithMaintenance(client, projectName, serviceName)
 	if err != nil {
 		if err = resourceReadHandleNotFound(err, d); err != nil {
 			return diag.FromErr(fmt.Errorf("unable to GET service %s: %s", d.Id(), err))
@@ -753,12 +832,30 @@ func resourceServiceRead(ctx context.Context, d *schema.ResourceData, m interfac
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := setServiceMaintenanceUpdates(d, maintenance); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var cost float64
+	if s.Powered {
+		if cost, err = service.EstimatedMonthlyCostUSD(m, client, projectName, s, servicePlanParams.DiskSizeMBDefault); err != nil {
+			// the cost is informational, it is left unchanged instead of failing the refresh
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  serviceCostNotRefreshedSummary,
+				Detail:   fmt.Sprintf("The estimated monthly cost of service %s cannot be refreshed: %s", d.Id(), err),
+			}}
+		}
+	}
+	if err := d.Set("estimated_monthly_cost_usd", cost); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
 
 func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	serviceType := d.Get("service_type").(string)
 	project := d.Get("project").(string)
@@ -771,6 +868,11 @@ func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interf
 		diskSpace = service.ConvertToDiskSpaceMB(ds.(string))
 	}
 
+	userConfig, err := serviceRestoreUserConfig(d, client, ConvertTerraformUserConfigToAPICompatibleFormat("service", serviceType, true, d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	if _, err := client.Services.Create(
 		project,
 		aiven.CreateServiceRequest{
@@ -783,7 +885,7 @@ func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interf
 			ServiceType:           serviceType,
 			TerminationProtection: d.Get("termination_protection").(bool),
 			DiskSpaceMB:           diskSpace,
-			UserConfig:            ConvertTerraformUserConfigToAPICompatibleFormat("service", serviceType, true, d),
+			UserConfig:            userConfig,
 		},
 	); err != nil {
 		return diag.FromErr(err)
@@ -796,16 +898,21 @@ func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 	d.SetId(buildResourceID(project, s.Name))
 
+	// services are always created running, a service that should be powered off is powered off afterwards
+	if !d.Get("powered").(bool) {
+		return resourceServiceUpdate(ctx, d, m)
+	}
+
 	return resourceServiceRead(ctx, d, m)
 }
 
 func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	var karapace *bool
 	if v := d.Get("karapace"); d.HasChange("karapace") && v != nil {
 		if k, ok := v.(bool); ok && k {
-			*karapace = true
+			karapace = &k
 		}
 	}
 
@@ -817,6 +924,15 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	projectName, serviceName := splitResourceID2(d.Id())
+	serviceType := d.Get("service_type").(string)
+	userConfig := ConvertTerraformUserConfigToAPICompatibleFormat("service", serviceType, false, d)
+
+	// the major version upgrades are checked before the service is updated
+	diags := service.CheckVersionUpgrades(ctx, client, projectName, serviceName, serviceType, userConfig,
+		d.Timeout(schema.TimeoutDefault))
+	if diags.HasError() {
+		return diags
+	}
 
 	if _, err := client.Services.Update(
 		projectName,
@@ -826,21 +942,40 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			Plan:                  d.Get("plan").(string),
 			MaintenanceWindow:     service.GetMaintenanceWindow(d),
 			ProjectVPCID:          service.GetProjectVPCIdPointer(d),
-			Powered:               true,
+			Powered:               d.Get("powered").(bool),
 			TerminationProtection: d.Get("termination_protection").(bool),
 			DiskSpaceMB:           diskSpace,
 			Karapace:              karapace,
-			UserConfig:            ConvertTerraformUserConfigToAPICompatibleFormat("service", d.Get("service_type").(string), false, d),
+			UserConfig:            userConfig,
 		},
 	); err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 
-	if _, err := resourceServiceWait(ctx, d, m, "update"); err != nil {
-		return diag.FromErr(err)
+	// a service that is powered on is rebuilt, it is waited to be running like a new service
+	operation := "update"
+	if d.HasChange("powered") && d.Get("powered").(bool) {
+		operation = "power_on"
 	}
 
-	return resourceServiceRead(ctx, d, m)
+	if _, err := resourceServiceWait(ctx, d, m, operation); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	costBefore, _ := d.GetChange("estimated_monthly_cost_usd")
+	readDiags := resourceServiceRead(ctx, d, m)
+	diags = append(diags, readDiags...)
+	if diags.HasError() || hasDiagnosticSummary(readDiags, serviceCostNotRefreshedSummary) {
+		return diags
+	}
+
+	summary := service.EstimatedMonthlyCostChangeSummary(d.Get("service_name").(string),
+		costBefore.(float64), d.Get("estimated_monthly_cost_usd").(float64))
+	if summary != "" {
+		diags = append(diags, diag.Diagnostic{Severity: diag.Warning, Summary: summary})
+	}
+
+	return diags
 }
 
 func getDefaultDiskSpaceIfNotSet(ctx context.Context, d *schema.ResourceData, client *aiven.Client) (int, error) {
@@ -861,7 +996,7 @@ func getDefaultDiskSpaceIfNotSet(ctx context.Context, d *schema.ResourceData, cl
 }
 
 func resourceServiceDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName := splitResourceID2(d.Id())
 
@@ -874,7 +1009,7 @@ func resourceServiceDelete(_ context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceServiceState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if len(strings.Split(d.Id(), "/")) != 2 {
 		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>", d.Id())
@@ -895,6 +1030,16 @@ func resourceServiceState(ctx context.Context, d *schema.ResourceData, m interfa
 		return nil, err
 	}
 
+	// the values of the createOnly options are not returned by the API, the options of the
+	// configuration are not changes of the imported service
+	definition, _ := templates.GetUserConfigSchema("service")[s.Type].(map[string]interface{})
+	if userConfig, ok := d.Get(s.Type + "_user_config").([]interface{}); ok && len(userConfig) > 0 {
+		markUserConfigCreateOnlyValuesUnknown(userConfig, definition)
+		if err := d.Set(s.Type+"_user_config", userConfig); err != nil {
+			return nil, err
+		}
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
 
@@ -907,15 +1052,17 @@ func resourceServiceWait(ctx context.Context, d *schema.ResourceData, m interfac
 	}
 
 	w := &ServiceChangeWaiter{
-		Client:      m.(*aiven.Client),
-		Operation:   operation,
-		Project:     d.Get("project").(string),
-		ServiceName: d.Get("service_name").(string),
+		Client:           m.(*providerMeta).client,
+		Operation:        operation,
+		Project:          d.Get("project").(string),
+		ServiceName:      d.Get("service_name").(string),
+		PoweredOff:       operation != "create" && !d.Get("powered").(bool),
+		WaitForMigration: serviceWaitForMigration(d, m),
 	}
 
 	s, err := w.Conf(timeout).WaitForStateContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error waiting for Aiven service to be RUNNING: %s", err)
+		return nil, fmt.Errorf("error waiting for Aiven service to be %s: %s", w.targetState(), err)
 	}
 
 	return s.(*aiven.Service), nil
@@ -950,6 +1097,9 @@ func copyServicePropertiesFromAPIResponseToTerraform(
 	if err := d.Set("termination_protection", s.TerminationProtection); err != nil {
 		return err
 	}
+	if err := d.Set("powered", s.Powered); err != nil {
+		return err
+	}
 	if err := d.Set("maintenance_window_dow", s.MaintenanceWindow.DayOfWeek); err != nil {
 		return err
 	}
@@ -980,12 +1130,31 @@ func copyServicePropertiesFromAPIResponseToTerraform(
 			return err
 		}
 	}
-	userConfig := ConvertAPIUserConfigToTerraformCompatibleFormat(
-		"service", serviceType, s.UserConfig)
-	if err := d.Set(serviceType+"_user_config",
-		ipfilter.Normalize(d.Get(serviceType+"_user_config"), userConfig)); err != nil {
-		return fmt.Errorf("cannot set `%s_user_config` : %s;"+
-			"Please make sure that all Aiven services have unique s names", serviceType, err)
+	userConfigDefinition, _ := templates.GetUserConfigSchema("service")[serviceType].(map[string]interface{})
+	apiUserConfig := s.UserConfig
+	if isUserConfigJSONUsed(d, serviceType) {
+		apiUserConfig = restoreUserConfigJSONCreateOnlyValues(apiUserConfig,
+			d.Get(serviceType+"_user_config_json").(string), userConfigDefinition)
+	}
+	if err := setUserConfigJSON(d, serviceType, apiUserConfig, func() error {
+		userConfig := ConvertAPIUserConfigToTerraformCompatibleFormat(
+			"service", serviceType, s.UserConfig)
+		restoreUserConfigSensitiveValues(userConfig, d.Get(serviceType+"_user_config"))
+		restoreUserConfigCreateOnlyValues(userConfig, d.Get(serviceType+"_user_config"), userConfigDefinition)
+		if err := d.Set(serviceType+"_user_config",
+			ipfilter.Normalize(d.Get(serviceType+"_user_config"), userConfig)); err != nil {
+			return fmt.Errorf("cannot set `%s_user_config` : %s;"+
+				"Please make sure that all Aiven services have unique s names", serviceType, err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// powered off services have no connection information, the values of the state are kept
+	// until the service is powered on again
+	if !s.Powered {
+		return nil
 	}
 
 	params := s.URIParams
@@ -1083,6 +1252,7 @@ func copyConnectionInfoFromAPIResponseToTerraform(
 	case "kafka_mirrormaker":
 	case "m3db":
 	case "m3aggregator":
+	case "m3coordinator":
 	default:
 		panic(fmt.Sprintf("Unsupported service type %v", serviceType))
 	}
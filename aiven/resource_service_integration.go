@@ -12,6 +12,7 @@ import (
 	"github.com/aiven/aiven-go-client"
 	"github.com/aiven/terraform-provider-aiven/aiven/templates"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -19,132 +20,92 @@ import (
 
 const serviceIntegrationEndpointRegExp = "^[a-zA-Z0-9_-]*\\/{1}[a-zA-Z0-9_-]*$"
 
-var aivenServiceIntegrationSchema = map[string]*schema.Schema{
-	"integration_id": {
-		Description: "Service Integration Id at aiven",
-		Computed:    true,
-		Type:        schema.TypeString,
-	},
-	"destination_endpoint_id": {
-		Description: "Destination endpoint for the integration (if any)",
-		ForceNew:    true,
-		Optional:    true,
-		Type:        schema.TypeString,
-		ValidateFunc: validation.StringMatch(regexp.MustCompile(serviceIntegrationEndpointRegExp),
-			"endpoint id should have the following format: project_name/endpoint_id"),
-	},
-	"destination_service_name": {
-		Description: "Destination service for the integration (if any)",
-		ForceNew:    true,
-		Optional:    true,
-		Type:        schema.TypeString,
-	},
-	"integration_type": {
-		Description: "Type of the service integration",
-		ForceNew:    true,
-		Required:    true,
-		Type:        schema.TypeString,
-	},
-	"logs_user_config": {
-		Description: "Log integration specific user configurable settings",
-		Elem: &schema.Resource{
-			Schema: GenerateTerraformUserConfigSchema(
-				templates.GetUserConfigSchema("integration")["logs"].(map[string]interface{})),
-		},
-		MaxItems: 1,
-		Optional: true,
-		Type:     schema.TypeList,
-	},
-	"mirrormaker_user_config": {
-		Description: "Mirrormaker 1 integration specific user configurable settings",
-		Elem: &schema.Resource{
-			Schema: GenerateTerraformUserConfigSchema(
-				templates.GetUserConfigSchema("integration")["mirrormaker"].(map[string]interface{})),
+// serviceIntegrationUserConfigDescriptions are the descriptions of the user config blocks of the
+// integration types, the other types use a description derived from the integration type
+var serviceIntegrationUserConfigDescriptions = map[string]string{
+	"datadog":           "Dashboard specific user configurable settings",
+	"kafka_connect":     "Kafka Connect specific user configurable settings",
+	"kafka_logs":        "Kafka Logs specific user configurable settings",
+	"kafka_mirrormaker": "Mirrormaker 2 integration specific user configurable settings",
+	"logs":              "Log integration specific user configurable settings",
+	"m3coordinator":     "M3 coordinator integration specific user configurable settings",
+	"metrics":           "Metrics specific user configurable settings",
+	"mirrormaker":       "Mirrormaker 1 integration specific user configurable settings",
+	"prometheus":        "Prometheus coordinator specific user configurable settings",
+}
+
+var aivenServiceIntegrationSchema = serviceIntegrationSchema()
+
+// serviceIntegrationSchema returns the schema of the service integration with a `<type>_user_config`
+// block and a `<type>_user_config_json` attribute for every integration type of the user config schemas
+func serviceIntegrationSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"integration_id": {
+			Description: "Service Integration Id at aiven",
+			Computed:    true,
+			Type:        schema.TypeString,
 		},
-		MaxItems: 1,
-		Optional: true,
-		Type:     schema.TypeList,
-	},
-	"kafka_mirrormaker_user_config": {
-		Description: "Mirrormaker 2 integration specific user configurable settings",
-		Elem: &schema.Resource{
-			Schema: GenerateTerraformUserConfigSchema(
-				templates.GetUserConfigSchema("integration")["kafka_mirrormaker"].(map[string]interface{})),
+		"destination_endpoint_id": {
+			Description: "Destination endpoint for the integration (if any)",
+			ForceNew:    true,
+			Optional:    true,
+			Type:        schema.TypeString,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(serviceIntegrationEndpointRegExp),
+				"endpoint id should have the following format: project_name/endpoint_id"),
 		},
-		MaxItems: 1,
-		Optional: true,
-		Type:     schema.TypeList,
-	},
-	"kafka_connect_user_config": {
-		Description: "Kafka Connect specific user configurable settings",
-		Elem: &schema.Resource{
-			Schema: GenerateTerraformUserConfigSchema(
-				templates.GetUserConfigSchema("integration")["kafka_connect"].(map[string]interface{})),
+		"destination_service_name": {
+			Description: "Destination service for the integration (if any)",
+			ForceNew:    true,
+			Optional:    true,
+			Type:        schema.TypeString,
 		},
-		MaxItems: 1,
-		Optional: true,
-		Type:     schema.TypeList,
-	},
-	"datadog_user_config": {
-		Description: "Dashboard specific user configurable settings",
-		Elem: &schema.Resource{
-			Schema: GenerateTerraformUserConfigSchema(
-				templates.GetUserConfigSchema("integration")["datadog"].(map[string]interface{})),
+		"integration_type": {
+			Description: "Type of the service integration",
+			ForceNew:    true,
+			Required:    true,
+			Type:        schema.TypeString,
 		},
-		MaxItems: 1,
-		Optional: true,
-		Type:     schema.TypeList,
-	},
-	"kafka_logs_user_config": {
-		Description: "Kafka Logs specific user configurable settings",
-		Elem: &schema.Resource{
-			Schema: GenerateTerraformUserConfigSchema(
-				templates.GetUserConfigSchema("integration")["kafka_logs"].(map[string]interface{})),
+		"project": {
+			Description: "Project the integration belongs to",
+			ForceNew:    true,
+			Required:    true,
+			Type:        schema.TypeString,
 		},
-		MaxItems: 1,
-		Optional: true,
-		Type:     schema.TypeList,
-	},
-	"prometheus_user_config": {
-		Description: "Prometheus coordinator specific user configurable settings",
-		Elem: &schema.Resource{
-			Schema: GenerateTerraformUserConfigSchema(
-				templates.GetUserConfigSchema("integration")["prometheus"].(map[string]interface{})),
+		"source_endpoint_id": {
+			Description: "Source endpoint for the integration (if any)",
+			ForceNew:    true,
+			Optional:    true,
+			Type:        schema.TypeString,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(serviceIntegrationEndpointRegExp),
+				"endpoint id should have the following format: project_name/endpoint_id"),
 		},
-		MaxItems: 1,
-		Optional: true,
-		Type:     schema.TypeList,
-	},
-	"metrics_user_config": {
-		Description: "Metrics specific user configurable settings",
-		Elem: &schema.Resource{
-			Schema: GenerateTerraformUserConfigSchema(
-				templates.GetUserConfigSchema("integration")["metrics"].(map[string]interface{})),
+		"source_service_name": {
+			Description: "Source service for the integration (if any)",
+			ForceNew:    true,
+			Optional:    true,
+			Type:        schema.TypeString,
 		},
-		MaxItems: 1,
-		Optional: true,
-		Type:     schema.TypeList,
-	},
-	"project": {
-		Description: "Project the integration belongs to",
-		ForceNew:    true,
-		Required:    true,
-		Type:        schema.TypeString,
-	},
-	"source_endpoint_id": {
-		Description: "Source endpoint for the integration (if any)",
-		ForceNew:    true,
-		Optional:    true,
-		Type:        schema.TypeString,
-		ValidateFunc: validation.StringMatch(regexp.MustCompile(serviceIntegrationEndpointRegExp),
-			"endpoint id should have the following format: project_name/endpoint_id"),
-	},
-	"source_service_name": {
-		Description: "Source service for the integration (if any)",
-		ForceNew:    true,
-		Optional:    true,
-		Type:        schema.TypeString,
-	},
+	}
+
+	for integrationType, definition := range templates.GetUserConfigSchema("integration") {
+		description, ok := serviceIntegrationUserConfigDescriptions[integrationType]
+		if !ok {
+			description = fmt.Sprintf("Integration type %s specific user configurable settings", integrationType)
+		}
+
+		s[integrationType+"_user_config"] = &schema.Schema{
+			Description: description,
+			Elem: &schema.Resource{
+				Schema: GenerateTerraformUserConfigSchema(definition.(map[string]interface{})),
+			},
+			MaxItems: 1,
+			Optional: true,
+			Type:     schema.TypeList,
+		}
+		s[integrationType+"_user_config_json"] = generateUserConfigurationJSON("integration", integrationType, description)
+	}
+
+	return s
 }
 
 func resourceServiceIntegration() *schema.Resource {
@@ -154,6 +115,10 @@ func resourceServiceIntegration() *schema.Resource {
 		ReadContext:   resourceServiceIntegrationRead,
 		UpdateContext: resourceServiceIntegrationUpdate,
 		DeleteContext: resourceServiceIntegrationDelete,
+		CustomizeDiff: customdiff.All(
+			customizeDiffServiceIntegrationUserConfig,
+			customizeDiffUserConfigJSON("integration", userConfigJSONEntryTypes(aivenServiceIntegrationSchema)...),
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceIntegrationState,
 		},
@@ -161,7 +126,9 @@ func resourceServiceIntegration() *schema.Resource {
 			Create: schema.DefaultTimeout(2 * time.Minute),
 		},
 
-		Schema: aivenServiceIntegrationSchema,
+		Schema:         aivenServiceIntegrationSchema,
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("integration", aivenServiceIntegrationSchema),
 	}
 }
 
@@ -174,7 +141,7 @@ func plainEndpointID(fullEndpointID *string) *string {
 }
 
 func resourceServiceIntegrationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName := d.Get("project").(string)
 	integrationType := d.Get("integration_type").(string)
@@ -215,7 +182,7 @@ func resourceServiceIntegrationCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceServiceIntegrationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, integrationID := splitResourceID2(d.Id())
 	integration, err := client.ServiceIntegrations.Get(projectName, integrationID)
@@ -231,11 +198,11 @@ func resourceServiceIntegrationRead(_ context.Context, d *schema.ResourceData, m
 		return diag.Errorf("cannot copy api response into terraform schema: %s", err)
 	}
 
-	return nil
+	return getUserConfigSchemas(m).projectStaleWarning(projectName)
 }
 
 func resourceServiceIntegrationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, integrationID := splitResourceID2(d.Id())
 
@@ -257,7 +224,7 @@ func resourceServiceIntegrationUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceServiceIntegrationDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, integrationID := splitResourceID2(d.Id())
 	err := client.ServiceIntegrations.Delete(projectName, integrationID)
@@ -269,7 +236,7 @@ func resourceServiceIntegrationDelete(_ context.Context, d *schema.ResourceData,
 }
 
 func resourceServiceIntegrationState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if len(strings.Split(d.Id(), "/")) != 2 {
 		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<integration_id>", d.Id())
@@ -289,7 +256,7 @@ func resourceServiceIntegrationState(_ context.Context, d *schema.ResourceData,
 }
 
 func resourceServiceIntegrationCheckForPreexistingResource(ctx context.Context, d *schema.ResourceData, m interface{}) (*aiven.ServiceIntegration, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName := d.Get("project").(string)
 	integrationType := d.Get("integration_type").(string)
@@ -321,7 +288,7 @@ func resourceServiceIntegrationWaitUntilActive(ctx context.Context, d *schema.Re
 		active    = "ACTIVE"
 		notActive = "NOTACTIVE"
 	)
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, integrationID := splitResourceID2(d.Id())
 
@@ -396,10 +363,40 @@ func resourceServiceIntegrationCopyAPIResponseToTerraform(
 		return err
 	}
 
-	userConfig := ConvertAPIUserConfigToTerraformCompatibleFormat("integration", integrationType, integration.UserConfig)
-	if len(userConfig) > 0 {
-		d.Set(integrationType+"_user_config", userConfig)
+	return setUserConfigJSON(d, integrationType, integration.UserConfig, func() error {
+		userConfig := ConvertAPIUserConfigToTerraformCompatibleFormat("integration", integrationType, integration.UserConfig)
+		restoreUserConfigSensitiveValues(userConfig, d.Get(integrationType+"_user_config"))
+		if len(userConfig) > 0 {
+			return d.Set(integrationType+"_user_config", userConfig)
+		}
+		return nil
+	})
+}
+
+// customizeDiffServiceIntegrationUserConfig only allows the user config of the integration type
+// of the service integration, the API ignores the user configs of the other types
+func customizeDiffServiceIntegrationUserConfig(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("integration_type") {
+		return nil
+	}
+	integrationType := d.Get("integration_type").(string)
+
+	var keys []string
+	for _, entryType := range userConfigEntryTypes(aivenServiceIntegrationSchema) {
+		if entryType == integrationType {
+			continue
+		}
+
+		if userConfig, ok := d.Get(entryType + "_user_config").([]interface{}); ok && len(userConfig) > 0 {
+			keys = append(keys, entryType+"_user_config")
+		}
+		if userConfigJSON, ok := d.Get(entryType + "_user_config_json").(string); ok && userConfigJSON != "" {
+			keys = append(keys, entryType+"_user_config_json")
+		}
 	}
 
+	if len(keys) > 0 {
+		return fmt.Errorf("%s: only the user config of the %s integration type can be set", strings.Join(keys, ", "), integrationType)
+	}
 	return nil
 }
@@ -48,6 +48,7 @@ var aivenServiceIntegrationEndpointSchema = map[string]*schema.Schema{
 		Optional: true,
 		Type:     schema.TypeList,
 	},
+	"datadog_user_config_json": generateUserConfigurationJSON("endpoint", "datadog", "Datadog specific user configurable settings"),
 	"prometheus_user_config": {
 		Description: "Prometheus specific user configurable settings",
 		Elem: &schema.Resource{
@@ -58,6 +59,7 @@ var aivenServiceIntegrationEndpointSchema = map[string]*schema.Schema{
 		Optional: true,
 		Type:     schema.TypeList,
 	},
+	"prometheus_user_config_json": generateUserConfigurationJSON("endpoint", "prometheus", "Prometheus specific user configurable settings"),
 	"rsyslog_user_config": {
 		Description: "rsyslog specific user configurable settings",
 		Elem: &schema.Resource{
@@ -68,6 +70,7 @@ var aivenServiceIntegrationEndpointSchema = map[string]*schema.Schema{
 		Optional: true,
 		Type:     schema.TypeList,
 	},
+	"rsyslog_user_config_json": generateUserConfigurationJSON("endpoint", "rsyslog", "rsyslog specific user configurable settings"),
 	"external_elasticsearch_logs_user_config": {
 		Description: "external elasticsearch specific user configurable settings",
 		Elem: &schema.Resource{
@@ -78,6 +81,7 @@ var aivenServiceIntegrationEndpointSchema = map[string]*schema.Schema{
 		Optional: true,
 		Type:     schema.TypeList,
 	},
+	"external_elasticsearch_logs_user_config_json": generateUserConfigurationJSON("endpoint", "external_elasticsearch_logs", "external elasticsearch specific user configurable settings"),
 	"external_aws_cloudwatch_logs_user_config": {
 		Description: "external AWS CloudWatch Logs specific user configurable settings",
 		Elem: &schema.Resource{
@@ -88,6 +92,7 @@ var aivenServiceIntegrationEndpointSchema = map[string]*schema.Schema{
 		Optional: true,
 		Type:     schema.TypeList,
 	},
+	"external_aws_cloudwatch_logs_user_config_json": generateUserConfigurationJSON("endpoint", "external_aws_cloudwatch_logs", "external AWS CloudWatch Logs specific user configurable settings"),
 	"external_google_cloud_logging_user_config": {
 		Description: "external Google Cloud Logginig specific user configurable settings",
 		Elem: &schema.Resource{
@@ -98,6 +103,7 @@ var aivenServiceIntegrationEndpointSchema = map[string]*schema.Schema{
 		Optional: true,
 		Type:     schema.TypeList,
 	},
+	"external_google_cloud_logging_user_config_json": generateUserConfigurationJSON("endpoint", "external_google_cloud_logging", "external Google Cloud Logginig specific user configurable settings"),
 	"external_kafka_user_config": {
 		Description: "external Kafka specific user configurable settings",
 		Elem: &schema.Resource{
@@ -108,6 +114,7 @@ var aivenServiceIntegrationEndpointSchema = map[string]*schema.Schema{
 		Optional: true,
 		Type:     schema.TypeList,
 	},
+	"external_kafka_user_config_json": generateUserConfigurationJSON("endpoint", "external_kafka", "external Kafka specific user configurable settings"),
 	"jolokia_user_config": {
 		Description: "Jolokia specific user configurable settings",
 		Elem: &schema.Resource{
@@ -118,6 +125,7 @@ var aivenServiceIntegrationEndpointSchema = map[string]*schema.Schema{
 		Optional: true,
 		Type:     schema.TypeList,
 	},
+	"jolokia_user_config_json": generateUserConfigurationJSON("endpoint", "jolokia", "Jolokia specific user configurable settings"),
 	"signalfx_user_config": {
 		Description: "Signalfx specific user configurable settings",
 		Elem: &schema.Resource{
@@ -128,6 +136,7 @@ var aivenServiceIntegrationEndpointSchema = map[string]*schema.Schema{
 		Optional: true,
 		Type:     schema.TypeList,
 	},
+	"signalfx_user_config_json": generateUserConfigurationJSON("endpoint", "signalfx", "Signalfx specific user configurable settings"),
 	"external_schema_registry_user_config": {
 		Description: "External schema registry specific user configurable settings",
 		Elem: &schema.Resource{
@@ -138,6 +147,7 @@ var aivenServiceIntegrationEndpointSchema = map[string]*schema.Schema{
 		Optional: true,
 		Type:     schema.TypeList,
 	},
+	"external_schema_registry_user_config_json": generateUserConfigurationJSON("endpoint", "external_schema_registry", "External schema registry specific user configurable settings"),
 	"external_aws_cloudwatch_metrics_user_config": {
 		Description: "External AWS cloudwatch mertrics specific user configurable settings",
 		Elem: &schema.Resource{
@@ -148,6 +158,7 @@ var aivenServiceIntegrationEndpointSchema = map[string]*schema.Schema{
 		Optional: true,
 		Type:     schema.TypeList,
 	},
+	"external_aws_cloudwatch_metrics_user_config_json": generateUserConfigurationJSON("endpoint", "external_aws_cloudwatch_metrics", "External AWS cloudwatch mertrics specific user configurable settings"),
 }
 
 func resourceServiceIntegrationEndpoint() *schema.Resource {
@@ -157,16 +168,19 @@ func resourceServiceIntegrationEndpoint() *schema.Resource {
 		ReadContext:   resourceServiceIntegrationEndpointRead,
 		UpdateContext: resourceServiceIntegrationEndpointUpdate,
 		DeleteContext: resourceServiceIntegrationEndpointDelete,
+		CustomizeDiff: customizeDiffUserConfigJSON("endpoint", userConfigJSONEntryTypes(aivenServiceIntegrationEndpointSchema)...),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceIntegrationEndpointState,
 		},
 
-		Schema: aivenServiceIntegrationEndpointSchema,
+		Schema:         aivenServiceIntegrationEndpointSchema,
+		SchemaVersion:  1,
+		StateUpgraders: userConfigStateUpgraders("endpoint", aivenServiceIntegrationEndpointSchema),
 	}
 }
 
 func resourceServiceIntegrationEndpointCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	projectName := d.Get("project").(string)
 	endpointType := d.Get("endpoint_type").(string)
 	userConfig := ConvertTerraformUserConfigToAPICompatibleFormat("endpoint", endpointType, true, d)
@@ -189,7 +203,7 @@ func resourceServiceIntegrationEndpointCreate(ctx context.Context, d *schema.Res
 }
 
 func resourceServiceIntegrationEndpointRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, endpointID := splitResourceID2(d.Id())
 	endpoint, err := client.ServiceIntegrationEndpoints.Get(projectName, endpointID)
@@ -202,11 +216,11 @@ func resourceServiceIntegrationEndpointRead(_ context.Context, d *schema.Resourc
 		return diag.FromErr(err)
 	}
 
-	return nil
+	return getUserConfigSchemas(m).projectStaleWarning(projectName)
 }
 
 func resourceServiceIntegrationEndpointUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, endpointID := splitResourceID2(d.Id())
 	endpointType := d.Get("endpoint_type").(string)
@@ -226,7 +240,7 @@ func resourceServiceIntegrationEndpointUpdate(ctx context.Context, d *schema.Res
 }
 
 func resourceServiceIntegrationEndpointDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, endpointID := splitResourceID2(d.Id())
 	err := client.ServiceIntegrationEndpoints.Delete(projectName, endpointID)
@@ -238,7 +252,7 @@ func resourceServiceIntegrationEndpointDelete(_ context.Context, d *schema.Resou
 }
 
 func resourceServiceIntegrationEndpointState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if len(strings.Split(d.Id(), "/")) != 2 {
 		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<endpoint_id>", d.Id())
@@ -267,9 +281,15 @@ func copyServiceIntegrationEndpointPropertiesFromAPIResponseToTerraform(
 	d.Set("endpoint_name", endpoint.EndpointName)
 	endpointType := endpoint.EndpointType
 	d.Set("endpoint_type", endpointType)
-	userConfig := ConvertAPIUserConfigToTerraformCompatibleFormat("endpoint", endpointType, endpoint.UserConfig)
-	if len(userConfig) > 0 {
-		d.Set(endpointType+"_user_config", userConfig)
+	if err := setUserConfigJSON(d, endpointType, endpoint.UserConfig, func() error {
+		userConfig := ConvertAPIUserConfigToTerraformCompatibleFormat("endpoint", endpointType, endpoint.UserConfig)
+		restoreUserConfigSensitiveValues(userConfig, d.Get(endpointType+"_user_config"))
+		if len(userConfig) > 0 {
+			d.Set(endpointType+"_user_config", userConfig)
+		}
+		return nil
+	}); err != nil {
+		return err
 	}
 	// Must coerse all values into strings
 	endpointConfig := map[string]string{}
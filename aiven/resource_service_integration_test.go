@@ -3,6 +3,7 @@
 package aiven
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"regexp"
@@ -12,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func init() {
@@ -512,7 +514,7 @@ func testAccServiceIntegrationShouldFailResource() string {
 }
 
 func testAccCheckAivenServiceIntegrationResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each aiven_service_integration is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -557,3 +559,34 @@ func testAccCheckAivenServiceIntegrationAttributes(n string) resource.TestCheckF
 		return nil
 	}
 }
+
+func Test_customizeDiffServiceIntegrationUserConfig(t *testing.T) {
+	r := resourceServiceIntegration()
+
+	diff := func(config map[string]interface{}) error {
+		config["project"] = "project"
+		_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+		return err
+	}
+
+	assert.NoError(t, diff(map[string]interface{}{
+		"integration_type": "external_aws_cloudwatch_metrics",
+		"external_aws_cloudwatch_metrics_user_config": []interface{}{map[string]interface{}{
+			"dropped_metrics": []interface{}{map[string]interface{}{"metric": "kafka_log_log_size", "field": "value"}},
+		}},
+	}))
+
+	assert.NoError(t, diff(map[string]interface{}{
+		"integration_type":         "read_replica",
+		"read_replica_user_config": []interface{}{map[string]interface{}{}},
+	}))
+
+	err := diff(map[string]interface{}{
+		"integration_type":          "metrics",
+		"logs_user_config":          []interface{}{map[string]interface{}{"elasticsearch_index_days_max": 3}},
+		"signalfx_user_config_json": `{}`,
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "logs_user_config, signalfx_user_config_json: only the user config of the metrics integration type can be set")
+	}
+}
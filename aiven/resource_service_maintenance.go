@@ -0,0 +1,145 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var aivenServiceMaintenanceSchema = map[string]*schema.Schema{
+	"project":      commonSchemaProjectReference,
+	"service_name": commonSchemaServiceNameReference,
+	"trigger": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Arbitrary value that starts the maintenance of the service when it changes, such as a date or a version number. The maintenance is also started when the resource is created.",
+	},
+	"maintenance_updates": maintenanceUpdatesSchema(),
+}
+
+func resourceServiceMaintenance() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Service Maintenance resource starts the maintenance of a service on demand, which installs the pending maintenance updates " +
+			"of the service without waiting for its maintenance window. The maintenance is started when the resource is created and when `trigger` changes.",
+		CreateContext: resourceServiceMaintenanceCreate,
+		ReadContext:   resourceServiceMaintenanceRead,
+		UpdateContext: resourceServiceMaintenanceUpdate,
+		DeleteContext: resourceServiceMaintenanceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServiceMaintenanceState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: aivenServiceMaintenanceSchema,
+	}
+}
+
+func resourceServiceMaintenanceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	if err := resourceServiceMaintenanceStart(ctx, d, m, schema.TimeoutCreate); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildResourceID(project, serviceName))
+
+	return resourceServiceMaintenanceRead(ctx, d, m)
+}
+
+func resourceServiceMaintenanceRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName := splitResourceID2(d.Id())
+	maintenance, err := aivenapi.GetServiceMaintenance(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("maintenance_updates", flattenServiceMaintenanceUpdates(maintenance.Updates)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceServiceMaintenanceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChange("trigger") {
+		if err := resourceServiceMaintenanceStart(ctx, d, m, schema.TimeoutUpdate); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceServiceMaintenanceRead(ctx, d, m)
+}
+
+// resourceServiceMaintenanceDelete only removes the resource from the state, the maintenance
+// updates that have been installed cannot be reverted
+func resourceServiceMaintenanceDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceServiceMaintenanceState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if len(strings.Split(d.Id(), "/")) != 2 {
+		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>", d.Id())
+	}
+
+	di := resourceServiceMaintenanceRead(ctx, d, m)
+	if di.HasError() {
+		return nil, fmt.Errorf("cannot get service maintenance %v", di)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceServiceMaintenanceStart starts the maintenance of the service when it has pending updates
+// and waits until the service is running again
+func resourceServiceMaintenanceStart(ctx context.Context, d *schema.ResourceData, m interface{}, timeoutKey string) error {
+	client := m.(*providerMeta).client
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	maintenance, err := aivenapi.GetServiceMaintenance(client, project, serviceName)
+	if err != nil {
+		return fmt.Errorf("cannot get the maintenance updates of service %s/%s: %w", project, serviceName, err)
+	}
+	if len(maintenance.Updates) == 0 {
+		log.Printf("[DEBUG] service %s/%s has no pending maintenance updates", project, serviceName)
+		return nil
+	}
+
+	if err := aivenapi.StartServiceMaintenance(client, project, serviceName); err != nil {
+		return fmt.Errorf("cannot start the maintenance of service %s/%s: %w", project, serviceName, err)
+	}
+
+	w := &ServiceChangeWaiter{
+		Client:           client,
+		Operation:        "maintenance",
+		Project:          project,
+		ServiceName:      serviceName,
+		WaitForMigration: true,
+	}
+
+	if _, err := w.Conf(d.Timeout(timeoutKey)).WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for the maintenance of service %s/%s to finish: %w", project, serviceName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateMaintenanceWindow(t *testing.T) {
+	for _, v := range []string{"monday", "sunday"} {
+		_, errs := validateMaintenanceWindowDow(v, "maintenance_window_dow")
+		assert.Empty(t, errs, v)
+	}
+	for _, v := range []string{"Monday", "mon", "never", ""} {
+		_, errs := validateMaintenanceWindowDow(v, "maintenance_window_dow")
+		assert.NotEmpty(t, errs, v)
+	}
+
+	for _, v := range []string{"00:00:00", "10:00:00", "23:59:59"} {
+		_, errs := validateMaintenanceWindowTime(v, "maintenance_window_time")
+		assert.Empty(t, errs, v)
+	}
+	for _, v := range []string{"24:00:00", "10:00", "1:00:00", "10:60:00", "10:00:00Z"} {
+		_, errs := validateMaintenanceWindowTime(v, "maintenance_window_time")
+		assert.NotEmpty(t, errs, v)
+	}
+}
+
+func Test_flattenServiceMaintenanceUpdates(t *testing.T) {
+	assert.Equal(t, []map[string]interface{}{}, flattenServiceMaintenanceUpdates(nil))
+	assert.Equal(t, []map[string]interface{}{{
+		"description": "Update to the latest PostgreSQL minor version",
+		"deadline":    "2022-02-01T10:00:00Z",
+		"start_after": "2022-01-17T10:00:00Z",
+	}}, flattenServiceMaintenanceUpdates([]aivenapi.ServiceMaintenanceUpdate{{
+		Description: "Update to the latest PostgreSQL minor version",
+		Deadline:    "2022-02-01T10:00:00Z",
+		StartAfter:  "2022-01-17T10:00:00Z",
+		StartAt:     "2022-01-20T10:00:00Z",
+	}}))
+}
+
+func TestAccAivenServiceMaintenance_basic(t *testing.T) {
+	resourceName := "aiven_service_maintenance.maintenance"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceMaintenanceResource(rName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "trigger", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "maintenance_updates.#"),
+					resource.TestCheckResourceAttrSet("aiven_pg.bar", "maintenance_updates.#"),
+				),
+			},
+			{
+				Config: testAccServiceMaintenanceResource(rName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "trigger", "2"),
+					resource.TestCheckResourceAttr("aiven_pg.bar", "state", "RUNNING"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServiceMaintenanceResource(name, trigger string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+		  project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+		  project                 = data.aiven_project.foo.project
+		  cloud_name              = "google-europe-west1"
+		  plan                    = "startup-4"
+		  service_name            = "test-acc-sr-%s"
+		  maintenance_window_dow  = "monday"
+		  maintenance_window_time = "10:00:00"
+		}
+
+		resource "aiven_service_maintenance" "maintenance" {
+		  project      = aiven_pg.bar.project
+		  service_name = aiven_pg.bar.service_name
+		  trigger      = "%s"
+		}`,
+		os.Getenv("AIVEN_PROJECT_NAME"), name, trigger)
+}
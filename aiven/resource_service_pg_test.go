@@ -315,7 +315,7 @@ func testAccCheckAivenServiceTerminationProtection(n string) resource.TestCheckF
 
 		projectName, serviceName := splitResourceID2(a["id"])
 
-		c := testAccProvider.Meta().(*aiven.Client)
+		c := testAccProvider.Meta().(*providerMeta).client
 
 		service, err := c.Services.Get(projectName, serviceName)
 		if err != nil {
@@ -7,6 +7,7 @@ import (
 	"os"
 	"reflect"
 	"sort"
+	"strconv"
 	"testing"
 
 	"github.com/aiven/aiven-go-client"
@@ -222,8 +223,26 @@ func testAccCheckAivenServiceCommonAttributes(n string) resource.TestCheckFunc {
 	}
 }
 
+// testAccCheckAivenServiceEstimatedMonthlyCost checks that a service that is powered on has a cost
+func testAccCheckAivenServiceEstimatedMonthlyCost(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r := s.RootModule().Resources[n]
+		a := r.Primary.Attributes
+
+		cost, err := strconv.ParseFloat(a["estimated_monthly_cost_usd"], 64)
+		if err != nil {
+			return fmt.Errorf("expected to get an estimated_monthly_cost_usd: %w", err)
+		}
+		if cost <= 0 {
+			return fmt.Errorf("expected a positive estimated_monthly_cost_usd, got %v", cost)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckAivenServiceResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 	// loop through the resources in state, verifying each service is destroyed
 	for _, rs := range s.RootModule().Resources {
 		var r []string
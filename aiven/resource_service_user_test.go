@@ -110,7 +110,7 @@ func TestAccAivenServiceUser_basic(t *testing.T) {
 }
 
 func testAccCheckAivenServiceUserResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each aiven_service_user is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -0,0 +1,283 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	staticIPCreatingState  = "creating"
+	staticIPCreatedState   = "created"
+	staticIPAvailableState = "available"
+	staticIPAssignedState  = "assigned"
+	staticIPDeletingState  = "deleting"
+	staticIPDeletedState   = "deleted"
+)
+
+var aivenStaticIPSchema = map[string]*schema.Schema{
+	"project": commonSchemaProjectReference,
+	"cloud_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Specifies the cloud that the static IP address is allocated in. It has to be the cloud of the services the address is associated with. This property cannot be changed, doing so forces recreation of the resource.",
+	},
+	"service_name": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Specifies the name of the service the static IP address is associated with. The service uses the associated addresses when its `static_ips` user config option is enabled. An address that is in use by a node of the service cannot be dissociated.",
+	},
+	"static_ip_address_id": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The static IP address ID",
+	},
+	"ip_address": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The static IP address",
+	},
+	"state": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The state of the static IP address. One of `creating`, `created`, `available`, `assigned`, `deleting` or `deleted`.",
+	},
+}
+
+func resourceStaticIP() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Static IP resource allows the allocation and management of Aiven static IP addresses and their association with services.",
+		CreateContext: resourceStaticIPCreate,
+		ReadContext:   resourceStaticIPRead,
+		UpdateContext: resourceStaticIPUpdate,
+		DeleteContext: resourceStaticIPDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceStaticIPState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: aivenStaticIPSchema,
+	}
+}
+
+func resourceStaticIPCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project := d.Get("project").(string)
+	ip, err := aivenapi.CreateStaticIP(client, project, d.Get("cloud_name").(string))
+	if err != nil {
+		return diag.Errorf("cannot create static ip: %s", err)
+	}
+
+	d.SetId(buildResourceID(project, ip.StaticIPAddressID))
+
+	if err := resourceStaticIPWait(ctx, d, m, schema.TimeoutCreate,
+		[]string{staticIPCreatingState}, []string{staticIPCreatedState}); err != nil {
+		return diag.Errorf("error waiting for static ip to be created: %s", err)
+	}
+
+	if serviceName := d.Get("service_name").(string); serviceName != "" {
+		if err := resourceStaticIPAssociate(ctx, d, m, schema.TimeoutCreate, serviceName); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStaticIPRead(ctx, d, m)
+}
+
+func resourceStaticIPRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, staticIPAddressID := splitResourceID2(d.Id())
+	ip, err := aivenapi.GetStaticIP(client, project, staticIPAddressID)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	if ip.State == staticIPDeletedState {
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("cloud_name", ip.CloudName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", ip.ServiceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("static_ip_address_id", ip.StaticIPAddressID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ip_address", ip.IPAddress); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("state", ip.State); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceStaticIPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if !d.HasChange("service_name") {
+		return resourceStaticIPRead(ctx, d, m)
+	}
+
+	old, new := d.GetChange("service_name")
+	if old.(string) != "" {
+		if err := resourceStaticIPDissociate(ctx, d, m, schema.TimeoutUpdate); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if new.(string) != "" {
+		if err := resourceStaticIPAssociate(ctx, d, m, schema.TimeoutUpdate, new.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStaticIPRead(ctx, d, m)
+}
+
+func resourceStaticIPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, staticIPAddressID := splitResourceID2(d.Id())
+	ip, err := aivenapi.GetStaticIP(client, project, staticIPAddressID)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return diag.Errorf("cannot get static ip: %s", err)
+	}
+
+	if ip.ServiceName != "" {
+		if err := resourceStaticIPDissociate(ctx, d, m, schema.TimeoutDelete); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := aivenapi.DeleteStaticIP(client, project, staticIPAddressID); err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot delete static ip: %s", err)
+	}
+
+	if err := resourceStaticIPWait(ctx, d, m, schema.TimeoutDelete,
+		[]string{staticIPCreatedState, staticIPDeletingState}, []string{staticIPDeletedState}); err != nil {
+		return diag.Errorf("error waiting for static ip to be deleted: %s", err)
+	}
+
+	return nil
+}
+
+func resourceStaticIPState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if len(strings.Split(d.Id(), "/")) != 2 {
+		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<static_ip_address_id>", d.Id())
+	}
+
+	di := resourceStaticIPRead(ctx, d, m)
+	if di.HasError() {
+		return nil, fmt.Errorf("cannot get static ip %v", di)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceStaticIPAssociate(ctx context.Context, d *schema.ResourceData, m interface{}, timeoutKey, serviceName string) error {
+	project, staticIPAddressID := splitResourceID2(d.Id())
+	if err := aivenapi.AssociateStaticIP(m.(*providerMeta).client, project, staticIPAddressID, serviceName); err != nil {
+		return fmt.Errorf("cannot associate static ip with service %s: %s", serviceName, err)
+	}
+
+	if err := resourceStaticIPWait(ctx, d, m, timeoutKey,
+		[]string{staticIPCreatedState}, []string{staticIPAvailableState, staticIPAssignedState}); err != nil {
+		return fmt.Errorf("error waiting for static ip to be associated: %s", err)
+	}
+	return nil
+}
+
+func resourceStaticIPDissociate(ctx context.Context, d *schema.ResourceData, m interface{}, timeoutKey string) error {
+	project, staticIPAddressID := splitResourceID2(d.Id())
+	if err := aivenapi.DissociateStaticIP(m.(*providerMeta).client, project, staticIPAddressID); err != nil {
+		return fmt.Errorf("cannot dissociate static ip: %s", err)
+	}
+
+	if err := resourceStaticIPWait(ctx, d, m, timeoutKey,
+		[]string{staticIPAvailableState, staticIPAssignedState}, []string{staticIPCreatedState}); err != nil {
+		return fmt.Errorf("error waiting for static ip to be dissociated: %s", err)
+	}
+	return nil
+}
+
+func resourceStaticIPWait(ctx context.Context, d *schema.ResourceData, m interface{}, timeoutKey string, pending, target []string) error {
+	project, staticIPAddressID := splitResourceID2(d.Id())
+
+	w := &StaticIPChangeWaiter{
+		Client:            m.(*providerMeta).client,
+		Project:           project,
+		StaticIPAddressID: staticIPAddressID,
+		Pending:           pending,
+		Target:            target,
+	}
+
+	_, err := w.Conf(d.Timeout(timeoutKey)).WaitForStateContext(ctx)
+	return err
+}
+
+// StaticIPChangeWaiter is used to wait for a static IP address to be allocated, associated,
+// dissociated or released
+type StaticIPChangeWaiter struct {
+	Client            *aiven.Client
+	Project           string
+	StaticIPAddressID string
+	Pending           []string
+	Target            []string
+}
+
+// RefreshFunc will call the Aiven client and refresh its state.
+func (w *StaticIPChangeWaiter) RefreshFunc() resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		ip, err := aivenapi.GetStaticIP(w.Client, w.Project, w.StaticIPAddressID)
+		if err != nil {
+			// a released address is removed from the list
+			if aiven.IsNotFound(err) {
+				return &aivenapi.StaticIP{StaticIPAddressID: w.StaticIPAddressID}, staticIPDeletedState, nil
+			}
+			return nil, "", err
+		}
+
+		log.Printf("[DEBUG] static ip %s/%s reports as %s, waiting for %s",
+			w.Project, w.StaticIPAddressID, ip.State, strings.Join(w.Target, " or "))
+
+		return ip, ip.State, nil
+	}
+}
+
+// Conf sets up the configuration to refresh.
+func (w *StaticIPChangeWaiter) Conf(timeout time.Duration) *resource.StateChangeConf {
+	log.Printf("[DEBUG] Static IP waiter timeout %.0f minutes", timeout.Minutes())
+
+	return &resource.StateChangeConf{
+		Pending:    w.Pending,
+		Target:     w.Target,
+		Refresh:    w.RefreshFunc(),
+		Delay:      2 * time.Second,
+		Timeout:    timeout,
+		MinTimeout: 2 * time.Second,
+	}
+}
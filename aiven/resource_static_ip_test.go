@@ -0,0 +1,98 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAivenStaticIP_basic(t *testing.T) {
+	resourceName := "aiven_static_ip.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenStaticIPResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStaticIPResource(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "cloud_name", "google-europe-west1"),
+					resource.TestCheckResourceAttr(resourceName, "state", "created"),
+					resource.TestCheckResourceAttrSet(resourceName, "ip_address"),
+					resource.TestCheckResourceAttrSet(resourceName, "static_ip_address_id"),
+				),
+			},
+			{
+				Config: testAccStaticIPResource(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "state", "available"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckAivenStaticIPResourceDestroy(s *terraform.State) error {
+	c := testAccProvider.Meta().(*providerMeta).client
+
+	// loop through the resources in state, verifying each static ip is released
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aiven_static_ip" {
+			continue
+		}
+
+		project, staticIPAddressID := splitResourceID2(rs.Primary.ID)
+		ip, err := aivenapi.GetStaticIP(c, project, staticIPAddressID)
+		if err != nil && !aiven.IsNotFound(err) {
+			return fmt.Errorf("error getting a static ip: %w", err)
+		}
+
+		if ip != nil && ip.State != "deleted" {
+			return fmt.Errorf("static ip (%s) still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccStaticIPResource(name string, associate bool) string {
+	serviceName := "null"
+	if associate {
+		serviceName = "aiven_pg.bar.service_name"
+	}
+
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+		  project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+		  project      = data.aiven_project.foo.project
+		  cloud_name   = "google-europe-west1"
+		  plan         = "startup-4"
+		  service_name = "test-acc-sr-%s"
+		}
+
+		resource "aiven_static_ip" "foo" {
+		  project      = data.aiven_project.foo.project
+		  cloud_name   = "google-europe-west1"
+		  service_name = %s
+		}`,
+		os.Getenv("AIVEN_PROJECT_NAME"), name, serviceName)
+}
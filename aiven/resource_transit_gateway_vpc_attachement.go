@@ -81,7 +81,7 @@ func resourceTransitGatewayVPCAttachment() *schema.Resource {
 }
 
 func resourceTransitGatewayVPCAttachmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	cidrs := flattenToString(d.Get("user_peer_network_cidrs").([]interface{}))
 	projectName, vpcID, peerCloudAccount, peerVPC, _ := parsePeeringVPCId(d.Id())
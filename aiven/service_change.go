@@ -3,9 +3,11 @@
 package aiven
 
 import (
+	"fmt"
 	"log"
 	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aiven/aiven-go-client"
@@ -19,6 +21,10 @@ type ServiceChangeWaiter struct {
 	Operation   string
 	Project     string
 	ServiceName string
+	// PoweredOff waits for the service to be powered off instead of running
+	PoweredOff bool
+	// WaitForMigration keeps waiting on update until the service is running after a migration
+	WaitForMigration bool
 }
 
 const (
@@ -26,6 +32,7 @@ const (
 	aivenPendingState          = "REBUILDING"
 	aivenRebalancingState      = "REBALANCING"
 	aivenServicesStartingState = "WAITING_FOR_SERVICES"
+	aivenPoweroffState         = "POWEROFF"
 )
 
 // RefreshFunc will call the Aiven client and refresh its state.
@@ -41,7 +48,12 @@ func (w *ServiceChangeWaiter) RefreshFunc() resource.StateRefreshFunc {
 		}
 
 		state := service.State
-		if w.Operation == "update" {
+		if w.PoweredOff {
+			log.Printf("[DEBUG] service reports as %s, waiting for it to be powered off", state)
+			return service, state, nil
+		}
+
+		if w.Operation == "update" && !w.WaitForMigration {
 			// When updating service don't wait for it to enter RUNNING state because that can take
 			// very long time if for example service plan or cloud it runs in is changed and the
 			// service has a lot of data. If the service was already previously in RUNNING state we
@@ -49,6 +61,11 @@ func (w *ServiceChangeWaiter) RefreshFunc() resource.StateRefreshFunc {
 			state = aivenTargetState
 		}
 
+		if state == aivenPendingState || state == aivenRebalancingState {
+			log.Printf("[INFO] service %s/%s is %s, waiting for the migration to finish: %s",
+				w.Project, w.ServiceName, state, migrationProgress(service))
+		}
+
 		if state == aivenTargetState && !backupsReady(service) {
 			log.Printf("[DEBUG] service reports as %s, still waiting for service backups", state)
 			return service, aivenServicesStartingState, nil
@@ -63,6 +80,26 @@ func (w *ServiceChangeWaiter) RefreshFunc() resource.StateRefreshFunc {
 	}
 }
 
+// migrationProgress describes the progress of the nodes of a service which is being rebuilt
+func migrationProgress(service *aiven.Service) string {
+	var nodes []string
+	for _, n := range service.NodeStates {
+		node := fmt.Sprintf("%s (%s) %s", n.Name, n.Role, n.State)
+		for _, p := range n.ProgressUpdates {
+			if !p.Completed {
+				node += fmt.Sprintf(", %s %d/%d %s", p.Phase, p.Current, p.Max, p.Unit)
+				break
+			}
+		}
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 0 {
+		return "no node states"
+	}
+	return strings.Join(nodes, "; ")
+}
+
 func grafanaReady(service *aiven.Service) bool {
 	if service.Type != "grafana" {
 		return true
@@ -132,9 +169,14 @@ func backupsReady(service *aiven.Service) bool {
 func (w *ServiceChangeWaiter) Conf(timeout time.Duration) *resource.StateChangeConf {
 	log.Printf("[DEBUG] Service waiter timeout %.0f minutes", timeout.Minutes())
 
+	pending := []string{aivenPendingState, aivenRebalancingState, aivenServicesStartingState}
+	if w.PoweredOff {
+		pending = append(pending, aivenTargetState)
+	}
+
 	return &resource.StateChangeConf{
-		Pending:                   []string{aivenPendingState, aivenRebalancingState, aivenServicesStartingState},
-		Target:                    []string{aivenTargetState},
+		Pending:                   pending,
+		Target:                    []string{w.targetState()},
 		Refresh:                   w.RefreshFunc(),
 		Delay:                     10 * time.Second,
 		Timeout:                   timeout,
@@ -142,3 +184,10 @@ func (w *ServiceChangeWaiter) Conf(timeout time.Duration) *resource.StateChangeC
 		ContinuousTargetOccurence: 5,
 	}
 }
+
+func (w *ServiceChangeWaiter) targetState() string {
+	if w.PoweredOff {
+		return aivenPoweroffState
+	}
+	return aivenTargetState
+}
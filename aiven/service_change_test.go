@@ -0,0 +1,23 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_migrationProgress(t *testing.T) {
+	assert.Equal(t, "no node states", migrationProgress(&aiven.Service{}))
+
+	assert.Equal(t, "pg-1 (master) running; pg-2 (standby) syncing_data, prepare 3/10 files", migrationProgress(&aiven.Service{
+		NodeStates: []*aiven.NodeState{
+			{Name: "pg-1", Role: "master", State: "running"},
+			{Name: "pg-2", Role: "standby", State: "syncing_data", ProgressUpdates: []aiven.ProgressUpdate{
+				{Completed: true, Phase: "prepare", Current: 1, Max: 1},
+				{Phase: "prepare", Current: 3, Max: 10, Unit: "files"},
+			}},
+		},
+	}))
+}
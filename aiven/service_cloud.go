@@ -0,0 +1,60 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// customizeDiffServiceCloudName fails the plan when the `cloud_name` of a service is not one of the
+// clouds available to the project, instead of failing when the service is created or updated
+func customizeDiffServiceCloudName(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client, ok := providerClient(m)
+	if !ok || client == nil {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("cloud_name") {
+		return nil
+	}
+	if !d.NewValueKnown("cloud_name") || !d.NewValueKnown("project") {
+		return nil
+	}
+
+	cloudName := d.Get("cloud_name").(string)
+	if cloudName == "" {
+		return nil
+	}
+	project := d.Get("project").(string)
+
+	clouds, err := aivenapi.ListClouds(client, project)
+	if err != nil {
+		return fmt.Errorf("cannot list the clouds of project %s: %w", project, err)
+	}
+
+	return checkCloudName(clouds, cloudName)
+}
+
+// checkCloudName checks that a cloud is in the list, the error lists the clouds of the same provider
+func checkCloudName(clouds []aivenapi.Cloud, cloudName string) error {
+	var similar []string
+	for _, c := range clouds {
+		if c.CloudName == cloudName {
+			return nil
+		}
+		if strings.HasPrefix(cloudName, c.Provider+"-") {
+			similar = append(similar, c.CloudName)
+		}
+	}
+
+	if len(similar) == 0 {
+		return fmt.Errorf("cloud_name: %s is not an available cloud, the `aiven_clouds` data source lists the clouds", cloudName)
+	}
+	sort.Strings(similar)
+	return fmt.Errorf("cloud_name: %s is not an available cloud, the available clouds of the provider are %s",
+		cloudName, strings.Join(similar, ", "))
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"regexp"
+
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// maintenanceWindowTimeRegExp matches the UTC times of day of the maintenance windows
+const maintenanceWindowTimeRegExp = `^([01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9]$`
+
+var maintenanceWindowDays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+var (
+	validateMaintenanceWindowDow  = validation.StringInSlice(maintenanceWindowDays, false)
+	validateMaintenanceWindowTime = validation.StringMatch(regexp.MustCompile(maintenanceWindowTimeRegExp),
+		"should be a UTC time of day in HH:mm:ss format, such as 10:00:00")
+)
+
+// maintenanceUpdatesSchema returns the schema of the pending maintenance updates of a service
+func maintenanceUpdatesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Description: "Maintenance updates that are pending for the service. They are installed during the maintenance window " +
+			"after `start_after` and before `deadline` at the latest, or when maintenance is started with the `aiven_service_maintenance` resource.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"description": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Description of the update.",
+				},
+				"deadline": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Time the update is installed by at the latest.",
+				},
+				"start_after": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Time after which the update is installed during the maintenance window.",
+				},
+			},
+		},
+	}
+}
+
+// setServiceMaintenanceUpdates sets the pending maintenance updates of a service
+func setServiceMaintenanceUpdates(d *schema.ResourceData, maintenance *aivenapi.ServiceMaintenance) error {
+	return d.Set("maintenance_updates", flattenServiceMaintenanceUpdates(maintenance.Updates))
+}
+
+func flattenServiceMaintenanceUpdates(updates []aivenapi.ServiceMaintenanceUpdate) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(updates))
+	for _, u := range updates {
+		result = append(result, map[string]interface{}{
+			"description": u.Description,
+			"deadline":    u.Deadline,
+			"start_after": u.StartAfter,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// serviceRestoreUserConfigOptions are the user config options which are set by the `restore` block
+var serviceRestoreUserConfigOptions = []string{
+	"project_to_fork_from",
+	"recovery_basebackup_name",
+	"recovery_target_time",
+	"service_to_fork_from",
+}
+
+// serviceRestoreSchema returns the schema of the `restore` block of a service type, the options of
+// the block depend on the fork options the user config of the service type supports
+func serviceRestoreSchema(serviceType string) *schema.Schema {
+	definition, _ := templates.GetUserConfigSchema("service")[serviceType].(map[string]interface{})
+	properties, _ := definition["properties"].(map[string]interface{})
+
+	s := map[string]*schema.Schema{
+		"source_service_name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Name of the service the data is restored from.",
+		},
+		"source_project": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Project of the service the data is restored from, defaults to the project of the service.",
+		},
+		"backup_time": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Time of the backup of the source service the data was restored from.",
+		},
+	}
+	if _, ok := properties["recovery_target_time"]; ok {
+		s["recovery_target_time"] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsRFC3339Time,
+			Description: "Point in time the data is restored to in RFC3339 format, such as `2022-01-10T10:00:00Z`. " +
+				"The latest data of the source service is restored when it is not set.",
+		}
+	}
+	if _, ok := properties["recovery_basebackup_name"]; ok {
+		s["backup_name"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Description: "Name of the backup of the source service to restore. The latest backup is restored when " +
+				"it is not set.",
+		}
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		MaxItems: 1,
+		Optional: true,
+		ForceNew: true,
+		Description: "Creates the service as a fork of another service with the data restored from the backups of the " +
+			"source service. The backup is checked to exist before the service is created. Changing the block " +
+			"replaces the service.",
+		Elem: &schema.Resource{Schema: s},
+	}
+}
+
+// customizeDiffServiceRestore fails the plan when the fork options are set both in the `restore`
+// block and in the user config, or when the recovery target time is in the future
+func customizeDiffServiceRestore(serviceType string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		if d.Id() != "" {
+			return nil
+		}
+		if restore, ok := d.Get("restore").([]interface{}); !ok || len(restore) == 0 {
+			return nil
+		}
+
+		var conflicts []string
+		for _, option := range serviceRestoreUserConfigOptions {
+			if v, ok := d.Get(serviceType + "_user_config.0." + option).(string); ok && v != "" {
+				conflicts = append(conflicts, serviceType+"_user_config."+option)
+			}
+		}
+		if d.NewValueKnown(serviceType + "_user_config_json") {
+			var userConfig map[string]interface{}
+			if s, ok := d.Get(serviceType + "_user_config_json").(string); ok && s != "" && json.Unmarshal([]byte(s), &userConfig) == nil {
+				for _, option := range serviceRestoreUserConfigOptions {
+					if userConfig[option] != nil {
+						conflicts = append(conflicts, serviceType+"_user_config_json."+option)
+					}
+				}
+			}
+		}
+		if len(conflicts) > 0 {
+			sort.Strings(conflicts)
+			return fmt.Errorf("%s: conflicts with the `restore` block", strings.Join(conflicts, ", "))
+		}
+
+		if d.NewValueKnown("restore.0.recovery_target_time") {
+			if v, ok := d.Get("restore.0.recovery_target_time").(string); ok && v != "" {
+				if t, err := time.Parse(time.RFC3339, v); err == nil && t.After(time.Now()) {
+					return fmt.Errorf("restore.0.recovery_target_time: %s is in the future", v)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// serviceRestoreUserConfig adds the fork options of the `restore` block to the user config of a
+// service which is being created. The backups of the source service are checked first, the time of
+// the backup the data is restored from is recorded in the block.
+func serviceRestoreUserConfig(d *schema.ResourceData, client *aiven.Client, userConfig map[string]interface{}) (map[string]interface{}, error) {
+	restore, ok := d.Get("restore").([]interface{})
+	if !ok || len(restore) == 0 || restore[0] == nil {
+		return userConfig, nil
+	}
+	r := restore[0].(map[string]interface{})
+
+	sourceService := r["source_service_name"].(string)
+	sourceProject, _ := r["source_project"].(string)
+	if sourceProject == "" {
+		sourceProject = d.Get("project").(string)
+	}
+	backupName, _ := r["backup_name"].(string)
+	recoveryTargetTime, _ := r["recovery_target_time"].(string)
+
+	backups, err := aivenapi.ListServiceBackups(client, sourceProject, sourceService)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the backups of the source service %s/%s: %w", sourceProject, sourceService, err)
+	}
+
+	backup, err := selectServiceRestoreBackup(backups, backupName, recoveryTargetTime)
+	if err != nil {
+		return nil, fmt.Errorf("cannot restore the source service %s/%s: %w", sourceProject, sourceService, err)
+	}
+
+	if userConfig == nil {
+		userConfig = make(map[string]interface{})
+	}
+	userConfig["service_to_fork_from"] = sourceService
+	if source, ok := r["source_project"].(string); ok && source != "" {
+		userConfig["project_to_fork_from"] = source
+	}
+	if backupName != "" {
+		userConfig["recovery_basebackup_name"] = backupName
+	}
+	if recoveryTargetTime != "" {
+		userConfig["recovery_target_time"] = recoveryTargetTime
+	}
+
+	r["backup_time"] = backup.BackupTime
+	if err := d.Set("restore", []interface{}{r}); err != nil {
+		return nil, err
+	}
+
+	return userConfig, nil
+}
+
+// selectServiceRestoreBackup returns the backup a service is restored from: the backup with the
+// given name, the latest backup before the recovery target time or the latest backup
+func selectServiceRestoreBackup(backups []aivenapi.ServiceBackup, backupName, recoveryTargetTime string) (*aivenapi.ServiceBackup, error) {
+	if len(backups) == 0 {
+		return nil, fmt.Errorf("the service has no backups")
+	}
+
+	if backupName != "" {
+		for i := range backups {
+			if backups[i].BackupName == backupName {
+				return &backups[i], nil
+			}
+		}
+		return nil, fmt.Errorf("backup %q does not exist", backupName)
+	}
+
+	targetTime := time.Now()
+	if recoveryTargetTime != "" {
+		t, err := time.Parse(time.RFC3339, recoveryTargetTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recovery target time %q: %w", recoveryTargetTime, err)
+		}
+		targetTime = t
+	}
+
+	var selected *aivenapi.ServiceBackup
+	var selectedTime, oldestTime time.Time
+	for i := range backups {
+		t, err := time.Parse(time.RFC3339Nano, backups[i].BackupTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid backup time %q: %w", backups[i].BackupTime, err)
+		}
+		if oldestTime.IsZero() || t.Before(oldestTime) {
+			oldestTime = t
+		}
+		if !t.After(targetTime) && (selected == nil || t.After(selectedTime)) {
+			selected, selectedTime = &backups[i], t
+		}
+	}
+
+	if selected == nil {
+		return nil, fmt.Errorf("recovery target time %s is before the oldest backup from %s",
+			recoveryTargetTime, oldestTime.Format(time.RFC3339))
+	}
+	return selected, nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_selectServiceRestoreBackup(t *testing.T) {
+	backups := []aivenapi.ServiceBackup{
+		{BackupName: "b", BackupTime: "2022-01-11T10:00:00.000000Z"},
+		{BackupName: "a", BackupTime: "2022-01-10T10:00:00.000000Z"},
+		{BackupName: "c", BackupTime: "2022-01-12T10:00:00.000000Z"},
+	}
+
+	tests := []struct {
+		name               string
+		backups            []aivenapi.ServiceBackup
+		backupName         string
+		recoveryTargetTime string
+		want               string
+		wantErr            string
+	}{
+		{name: "latest", backups: backups, want: "c"},
+		{name: "by name", backups: backups, backupName: "a", want: "a"},
+		{name: "before target time", backups: backups, recoveryTargetTime: "2022-01-11T12:00:00+02:00", want: "b"},
+		{name: "no backups", wantErr: "the service has no backups"},
+		{name: "unknown name", backups: backups, backupName: "d", wantErr: `backup "d" does not exist`},
+		{
+			name:               "before oldest backup",
+			backups:            backups,
+			recoveryTargetTime: "2022-01-09T10:00:00Z",
+			wantErr:            "is before the oldest backup from 2022-01-10T10:00:00Z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectServiceRestoreBackup(tt.backups, tt.backupName, tt.recoveryTargetTime)
+			if tt.wantErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got.BackupName)
+			}
+		})
+	}
+}
+
+func Test_customizeDiffServiceRestore(t *testing.T) {
+	r := resourcePG()
+
+	diff := func(config map[string]interface{}) error {
+		config["project"] = "project"
+		config["service_name"] = "scratch"
+		_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+		return err
+	}
+
+	assert.NoError(t, diff(map[string]interface{}{
+		"restore": []interface{}{map[string]interface{}{
+			"source_service_name":  "production",
+			"recovery_target_time": "2022-01-10T10:00:00Z",
+		}},
+	}))
+
+	err := diff(map[string]interface{}{
+		"restore": []interface{}{map[string]interface{}{"source_service_name": "production"}},
+		"pg_user_config": []interface{}{map[string]interface{}{
+			"service_to_fork_from": "production",
+		}},
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "pg_user_config.service_to_fork_from: conflicts with the `restore` block")
+	}
+
+	diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project":      "project",
+		"service_name": "scratch",
+		"restore": []interface{}{map[string]interface{}{
+			"source_service_name":  "production",
+			"recovery_target_time": "2022-01-10 10:00:00",
+		}},
+	}))
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Summary, "RFC3339")
+	}
+
+	err = diff(map[string]interface{}{
+		"restore": []interface{}{map[string]interface{}{
+			"source_service_name":  "production",
+			"recovery_target_time": "2999-01-10T10:00:00Z",
+		}},
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is in the future")
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// customizeDiffServiceStaticIPs fails the plan when the `static_ips` user config option of an
+// existing service is enabled before a static IP address is associated with each node of the
+// service in the cloud of the service
+func customizeDiffServiceStaticIPs(serviceTypes ...string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+		client, ok := providerClient(m)
+		if !ok || client == nil {
+			return nil
+		}
+
+		for _, serviceType := range serviceTypes {
+			definition, _ := templates.GetUserConfigSchema("service")[serviceType].(map[string]interface{})
+			properties, _ := definition["properties"].(map[string]interface{})
+			if _, ok := properties["static_ips"]; !ok {
+				continue
+			}
+
+			key, enabled := serviceStaticIPsEnabled(d, serviceType)
+			if !enabled {
+				continue
+			}
+
+			for _, k := range []string{"project", "service_name", "cloud_name", "plan"} {
+				if !d.NewValueKnown(k) {
+					return nil
+				}
+			}
+			project := d.Get("project").(string)
+			serviceName := d.Get("service_name").(string)
+			cloudName := d.Get("cloud_name").(string)
+
+			plan, err := aivenapi.GetServicePlan(client, project, serviceType, d.Get("plan").(string))
+			if err != nil {
+				return fmt.Errorf("cannot get the plan of the service: %w", err)
+			}
+
+			ips, err := aivenapi.ListStaticIPs(client, project)
+			if err != nil {
+				return fmt.Errorf("cannot list the static ips of the project: %w", err)
+			}
+
+			if associated := countServiceStaticIPs(ips, serviceName, cloudName); associated < plan.NodeCount {
+				return fmt.Errorf("%s: the %s plan needs %d static ips associated with the service in %s, "+
+					"%d are associated, associate them with `aiven_static_ip` resources first",
+					key, plan.ServicePlan, plan.NodeCount, cloudName, associated)
+			}
+		}
+
+		return nil
+	}
+}
+
+// serviceStaticIPsEnabled checks if the `static_ips` user config option of a service type is
+// changed to true and returns the key of the attribute that enables it
+func serviceStaticIPsEnabled(d *schema.ResourceDiff, serviceType string) (string, bool) {
+	blockKey := serviceType + "_user_config.0.static_ips"
+	if d.HasChange(blockKey) && d.NewValueKnown(blockKey) {
+		if enabled, ok := d.Get(blockKey).(bool); ok && enabled {
+			return blockKey, true
+		}
+	}
+
+	jsonKey := serviceType + "_user_config_json"
+	if d.HasChange(jsonKey) && d.NewValueKnown(jsonKey) {
+		old, new := d.GetChange(jsonKey)
+		if !userConfigJSONStaticIPs(old) && userConfigJSONStaticIPs(new) {
+			return jsonKey, true
+		}
+	}
+
+	return "", false
+}
+
+// userConfigJSONStaticIPs checks if the `static_ips` option of a `<type>_user_config_json` value is true
+func userConfigJSONStaticIPs(v interface{}) bool {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return false
+	}
+
+	var userConfig map[string]interface{}
+	if json.Unmarshal([]byte(s), &userConfig) != nil {
+		return false
+	}
+
+	enabled, ok := userConfig["static_ips"].(bool)
+	return ok && enabled
+}
+
+// countServiceStaticIPs returns the number of static IP addresses that are associated with a
+// service in a cloud
+func countServiceStaticIPs(ips []aivenapi.StaticIP, serviceName, cloudName string) int {
+	var count int
+	for _, ip := range ips {
+		if ip.ServiceName != serviceName || ip.CloudName != cloudName {
+			continue
+		}
+		if ip.State == staticIPAvailableState || ip.State == staticIPAssignedState {
+			count++
+		}
+	}
+
+	return count
+}
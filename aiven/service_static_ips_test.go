@@ -0,0 +1,33 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"testing"
+
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_countServiceStaticIPs(t *testing.T) {
+	ips := []aivenapi.StaticIP{
+		{CloudName: "google-europe-west1", ServiceName: "pg", State: "assigned"},
+		{CloudName: "google-europe-west1", ServiceName: "pg", State: "available"},
+		{CloudName: "google-europe-west1", ServiceName: "pg", State: "creating"},
+		{CloudName: "google-europe-west1", ServiceName: "kafka", State: "available"},
+		{CloudName: "aws-eu-west-1", ServiceName: "pg", State: "available"},
+		{CloudName: "google-europe-west1", State: "created"},
+	}
+
+	assert.Equal(t, 2, countServiceStaticIPs(ips, "pg", "google-europe-west1"))
+	assert.Equal(t, 1, countServiceStaticIPs(ips, "pg", "aws-eu-west-1"))
+	assert.Equal(t, 0, countServiceStaticIPs(ips, "redis", "google-europe-west1"))
+}
+
+func Test_userConfigJSONStaticIPs(t *testing.T) {
+	assert.True(t, userConfigJSONStaticIPs(`{"static_ips": true}`))
+	assert.False(t, userConfigJSONStaticIPs(`{"static_ips": false}`))
+	assert.False(t, userConfigJSONStaticIPs(`{"pg_version": "14"}`))
+	assert.False(t, userConfigJSONStaticIPs(`not json`))
+	assert.False(t, userConfigJSONStaticIPs(""))
+	assert.False(t, userConfigJSONStaticIPs(nil))
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -24,6 +25,15 @@ func GenerateTerraformUserConfigSchema(data map[string]interface{}) map[string]*
 
 	for name, definitionRaw := range properties {
 		definition := definitionRaw.(map[string]interface{})
+
+		// arrays with oneOf items have an attribute for each of the alternatives
+		if attributes := userConfigOneOfAttributes(name, definition); len(attributes) > 0 {
+			for _, attribute := range attributes {
+				terraformSchema[encodeKeyName(attribute.name)] = generateTerraformUserConfigSchema(attribute.name, attribute.definition)
+			}
+			continue
+		}
+
 		terraformSchema[encodeKeyName(name)] = generateTerraformUserConfigSchema(name, definition)
 	}
 
@@ -32,15 +42,11 @@ func GenerateTerraformUserConfigSchema(data map[string]interface{}) map[string]*
 
 func generateTerraformUserConfigSchema(key string, definition map[string]interface{}) *schema.Schema {
 	valueType := getAivenSchemaType(definition["type"])
-	sensitive := false
-
-	if strings.Contains(key, "api_key") || strings.Contains(key, "password") {
-		sensitive = true
-	}
+	sensitive := isUserConfigSensitive(key, definition)
 
 	var diffFunction schema.SchemaDiffSuppressFunc
 	if createOnly, ok := definition["createOnly"]; ok && createOnly.(bool) {
-		diffFunction = createOnlyDiffSuppressFunc
+		diffFunction = userConfigCreateOnlyDiffSuppressFunc
 	} else if valueType == "object" {
 		diffFunction = emptyObjectDiffSuppressFuncSkipArrays(GenerateTerraformUserConfigSchema(definition))
 	}
@@ -54,7 +60,8 @@ func generateTerraformUserConfigSchema(key string, definition map[string]interfa
 			DiffSuppressFunc: diffFunction,
 			Optional:         true,
 			Sensitive:        sensitive,
-			Type:             schema.TypeString,
+			Type:             getTerraformSchemaType(valueType),
+			ValidateFunc:     generateTerraformUserConfigValidateFunc(definition),
 		}
 	case "object":
 		return &schema.Schema{
@@ -73,7 +80,7 @@ func generateTerraformUserConfigSchema(key string, definition map[string]interfa
 		typeString := getAivenSchemaType(itemDefinition["type"])
 		switch typeString {
 		case "string", "integer", "boolean", "number":
-			itemType = schema.TypeString
+			itemType = getTerraformSchemaType(typeString)
 		case "object":
 			itemType = schema.TypeList
 		default:
@@ -90,12 +97,13 @@ func generateTerraformUserConfigSchema(key string, definition map[string]interfa
 			valueDiffFunction = ipFilterValueDiffSuppressFunc
 		}
 		var elem interface{}
-		if itemType == schema.TypeList {
+		if typeString == "object" {
 			elem = &schema.Resource{Schema: GenerateTerraformUserConfigSchema(itemDefinition)}
 		} else {
 			elem = &schema.Schema{
 				DiffSuppressFunc: valueDiffFunction,
 				Type:             itemType,
+				ValidateFunc:     generateTerraformUserConfigValidateFunc(itemDefinition),
 			}
 		}
 		return &schema.Schema{
@@ -131,6 +139,20 @@ func getAivenSchemaType(value interface{}) string {
 	}
 }
 
+// getTerraformSchemaType returns the Terraform type of a scalar user config option
+func getTerraformSchemaType(valueType string) schema.ValueType {
+	switch valueType {
+	case "integer":
+		return schema.TypeInt
+	case "number":
+		return schema.TypeFloat
+	case "boolean":
+		return schema.TypeBool
+	default:
+		return schema.TypeString
+	}
+}
+
 func getAivenSchemaDefaultValue(definition map[string]interface{}) interface{} {
 	var defaultValue interface{}
 
@@ -140,6 +162,12 @@ func getAivenSchemaDefaultValue(definition map[string]interface{}) interface{} {
 		defaultValue = []interface{}{}
 	case "object":
 		defaultValue = []map[string]interface{}{}
+	case "integer":
+		defaultValue = 0
+	case "number":
+		defaultValue = float64(0)
+	case "boolean":
+		defaultValue = false
 	default:
 		defaultValue = ""
 	}
@@ -149,7 +177,8 @@ func getAivenSchemaDefaultValue(definition map[string]interface{}) interface{} {
 
 // ConvertAPIUserConfigToTerraformCompatibleFormat converts API response to a format that is
 // accepted by Terraform; intermediary lists are added as necessary, default values are provided
-// for missing keys and type conversions are performed if necessary.
+// for missing keys and type conversions are performed if necessary. The values of sensitive options
+// are masked, resources restore them from the state with restoreUserConfigSensitiveValues.
 func ConvertAPIUserConfigToTerraformCompatibleFormat(
 	configType string,
 	entryType string,
@@ -160,7 +189,7 @@ func ConvertAPIUserConfigToTerraformCompatibleFormat(
 	}
 
 	entrySchema := templates.GetUserConfigSchema(configType)[entryType].(map[string]interface{})
-	entrySchemaProps := entrySchema["properties"].(map[string]interface{})
+	entrySchemaProps, _ := entrySchema["properties"].(map[string]interface{})
 	return []map[string]interface{}{convertAPIUserConfigToTerraformCompatibleFormat(userConfig, entrySchemaProps)}
 }
 
@@ -176,6 +205,7 @@ func convertAPIUserConfigToTerraformCompatibleFormat(
 		valueType := getAivenSchemaType(schemaDefinition["type"])
 
 		apiValue, ok := apiUserConfig[key]
+		oneOfAttributes := userConfigOneOfAttributes(key, schemaDefinition)
 		key = encodeKeyName(key)
 		if !ok || apiValue == nil {
 			// To avoid undesired "changes" for values that are not explicitly defined return
@@ -192,45 +222,113 @@ func convertAPIUserConfigToTerraformCompatibleFormat(
 				apiValue.(map[string]interface{}), schemaDefinition["properties"].(map[string]interface{}),
 			)
 			terraformConfig[key] = []map[string]interface{}{res}
-		default:
-			switch value := apiValue.(type) {
-			case string:
-				terraformConfig[key] = apiValue
-			case bool:
-				terraformConfig[key] = strconv.FormatBool(apiValue.(bool))
-			case float64:
-				terraformConfig[key] = strconv.FormatFloat(apiValue.(float64), 'f', -1, 64)
-			case float32:
-				terraformConfig[key] = strconv.FormatFloat(apiValue.(float64), 'f', -1, 32)
-			case int:
-				terraformConfig[key] = strconv.Itoa(apiValue.(int))
-			case []interface{}:
-				if hasNestedUserConfigurationOptionItems(apiValue, schemaDefinition) {
-					var list []interface{}
-					for _, v := range apiValue.([]interface{}) {
-						res := convertAPIUserConfigToTerraformCompatibleFormat(
-							v.(map[string]interface{}), schemaDefinition["items"].(map[string]interface{})["properties"].(map[string]interface{}),
-						)
-						list = append(list, res)
-					}
-					terraformConfig[key] = list
-				} else {
-					var list []interface{}
-					for _, v := range apiValue.([]interface{}) {
-						list = append(list, fmt.Sprintf("%v", v))
-					}
-					terraformConfig[key] = list
+		case "array":
+			values, ok := apiValue.([]interface{})
+			if !ok {
+				panic(fmt.Sprintf("Invalid user config key type %T for %v", apiValue, key))
+			}
+
+			if len(oneOfAttributes) > 0 {
+				for name, list := range convertAPIUserConfigOneOfToTerraformCompatibleFormat(values, oneOfAttributes) {
+					terraformConfig[name] = list
 				}
+				continue
+			}
 
-			default:
-				panic(fmt.Sprintf("Invalid user config key type %T for %v", value, key))
+			var list []interface{}
+			if hasNestedUserConfigurationOptionItems(apiValue, schemaDefinition) {
+				for _, v := range values {
+					res := convertAPIUserConfigToTerraformCompatibleFormat(
+						v.(map[string]interface{}), schemaDefinition["items"].(map[string]interface{})["properties"].(map[string]interface{}),
+					)
+					list = append(list, res)
+				}
+			} else {
+				itemDefinition := selectFirstSchemaFromOneOf(schemaDefinition["items"].(map[string]interface{}))
+				itemType := getAivenSchemaType(itemDefinition["type"])
+				for _, v := range values {
+					v = maskUserConfigSensitiveValue(key, schemaDefinition, v)
+					list = append(list, convertAPIUserConfigValueToTerraformCompatibleFormat(itemType, key, v))
+				}
 			}
+			terraformConfig[key] = list
+		default:
+			apiValue = maskUserConfigSensitiveValue(key, schemaDefinition, apiValue)
+			terraformConfig[key] = convertAPIUserConfigValueToTerraformCompatibleFormat(valueType, key, apiValue)
 		}
 	}
 
 	return terraformConfig
 }
 
+// convertAPIUserConfigValueToTerraformCompatibleFormat converts a scalar API value to the
+// Terraform type of the user config option
+func convertAPIUserConfigValueToTerraformCompatibleFormat(valueType, key string, apiValue interface{}) interface{} {
+	switch valueType {
+	case "integer":
+		switch value := apiValue.(type) {
+		case float64:
+			return int(value)
+		case int:
+			return value
+		}
+	case "number":
+		switch value := apiValue.(type) {
+		case float64:
+			return value
+		case int:
+			return float64(value)
+		}
+	case "boolean":
+		if value, ok := apiValue.(bool); ok {
+			return value
+		}
+	default:
+		switch value := apiValue.(type) {
+		case string:
+			return value
+		case bool:
+			return strconv.FormatBool(value)
+		case float64:
+			return strconv.FormatFloat(value, 'f', -1, 64)
+		case int:
+			return strconv.Itoa(value)
+		default:
+			return fmt.Sprintf("%v", value)
+		}
+	}
+
+	panic(fmt.Sprintf("Invalid user config key type %T for %v", apiValue, key))
+}
+
+// convertAPIUserConfigOneOfToTerraformCompatibleFormat splits the items of an array with oneOf items
+// to the attributes of the alternatives they match
+func convertAPIUserConfigOneOfToTerraformCompatibleFormat(
+	values []interface{},
+	attributes []userConfigOneOfAttribute,
+) map[string][]interface{} {
+	lists := make(map[string][]interface{}, len(attributes))
+	for _, attribute := range attributes {
+		lists[encodeKeyName(attribute.name)] = nil
+	}
+
+	for _, v := range values {
+		attribute := attributes[matchUserConfigOneOfAttribute(v, attributes)]
+		name := encodeKeyName(attribute.name)
+		itemDefinition := attribute.definition["items"].(map[string]interface{})
+
+		switch itemType := getAivenSchemaType(itemDefinition["type"]); itemType {
+		case "object":
+			properties, _ := itemDefinition["properties"].(map[string]interface{})
+			lists[name] = append(lists[name], convertAPIUserConfigToTerraformCompatibleFormat(v.(map[string]interface{}), properties))
+		default:
+			lists[name] = append(lists[name], convertAPIUserConfigValueToTerraformCompatibleFormat(itemType, name, v))
+		}
+	}
+
+	return lists
+}
+
 // hasNestedUserConfigurationOptionItems determines if the user configuration option has nested
 // items by definition and base on API value.
 func hasNestedUserConfigurationOptionItems(apiValue interface{}, schemaDefinition map[string]interface{}) bool {
@@ -255,22 +353,40 @@ func hasNestedUserConfigurationOptionItems(apiValue interface{}, schemaDefinitio
 
 // ConvertTerraformUserConfigToAPICompatibleFormat converts Terraform user configuration to API compatible
 // format; Schema-based Terraform configuration requires using TypeList, which adds one extra layer of lists
-// that need to be dropped. Also need to drop dummy "unset" replacement values
+// that need to be dropped. Also need to drop dummy "unset" replacement values and the values that are not
+// set in the configuration, typed options have a zero value in that case. When the `<type>_user_config_json`
+// attribute is used instead of the block it is decoded as is.
 func ConvertTerraformUserConfigToAPICompatibleFormat(
 	configType string,
 	entryType string,
 	newResource bool,
 	d *schema.ResourceData,
 ) map[string]interface{} {
+	if isUserConfigJSONUsed(d, entryType) {
+		userConfig, err := convertTerraformUserConfigJSONToAPICompatibleFormat(
+			configType, entryType, newResource, d.Get(entryType+"_user_config_json").(string))
+		if err != nil {
+			panic(fmt.Sprintf("unable to convert %v user config: %s", entryType, err))
+		}
+		return userConfig
+	}
+
 	mainKey := entryType + "_user_config"
 	userConfigsRaw, ok := d.GetOk(mainKey)
 	if !ok || userConfigsRaw == nil {
 		return nil
 	}
+	// the blocks of the types without options are empty
+	userConfig, _ := userConfigsRaw.([]interface{})[0].(map[string]interface{})
 	entrySchema := templates.GetUserConfigSchema(configType)[entryType].(map[string]interface{})
-	entrySchemaProps := entrySchema["properties"].(map[string]interface{})
+	entrySchemaProps, _ := entrySchema["properties"].(map[string]interface{})
 	return convertTerraformUserConfigToAPICompatibleFormat(
-		entryType, newResource, userConfigsRaw.([]interface{})[0].(map[string]interface{}), entrySchemaProps)
+		entryType,
+		newResource,
+		userConfig,
+		entrySchemaProps,
+		rawConfigValue(resourceRawConfig(d), mainKey+".0"),
+	)
 }
 
 func convertTerraformUserConfigToAPICompatibleFormat(
@@ -278,11 +394,28 @@ func convertTerraformUserConfigToAPICompatibleFormat(
 	newResource bool,
 	userConfig map[string]interface{},
 	configSchema map[string]interface{},
+	rawConfig cty.Value,
 ) map[string]interface{} {
 	apiConfig := make(map[string]interface{})
 
+	// the attributes of oneOf alternatives are converted together with the array they belong to
+	oneOfAlternatives := make(map[string]bool)
+	for key, definitionRaw := range configSchema {
+		if definition, ok := definitionRaw.(map[string]interface{}); ok {
+			for _, attribute := range userConfigOneOfAttributes(key, definition) {
+				if attribute.name != key {
+					oneOfAlternatives[attribute.name] = true
+				}
+			}
+		}
+	}
+
 	for key, value := range userConfig {
+		rawValue := rawConfigAttribute(rawConfig, key)
 		key = decodeKeyName(key)
+		if oneOfAlternatives[key] {
+			continue
+		}
 		definitionRaw, ok := configSchema[key]
 		if !ok {
 			panic(fmt.Sprintf("Unsupported %v user config key %v", serviceType, key))
@@ -295,8 +428,16 @@ func convertTerraformUserConfigToAPICompatibleFormat(
 		if ok && createOnly.(bool) && !newResource {
 			continue
 		}
-		convertedValue, omit := convertTerraformUserConfigValueToAPICompatibleFormat(
-			serviceType, newResource, key, value, definition)
+
+		var convertedValue interface{}
+		var omit bool
+		if attributes := userConfigOneOfAttributes(key, definition); len(attributes) > 0 {
+			convertedValue, omit = convertTerraformUserConfigOneOfToAPICompatibleFormat(
+				serviceType, newResource, key, userConfig, attributes, rawConfig)
+		} else {
+			convertedValue, omit = convertTerraformUserConfigValueToAPICompatibleFormat(
+				serviceType, newResource, key, value, definition, rawValue)
+		}
 		if !omit {
 			apiConfig[key] = convertedValue
 		}
@@ -305,12 +446,47 @@ func convertTerraformUserConfigToAPICompatibleFormat(
 	return apiConfig
 }
 
+// convertTerraformUserConfigOneOfToAPICompatibleFormat joins the items of the attributes of
+// oneOf alternatives to a single array
+func convertTerraformUserConfigOneOfToAPICompatibleFormat(
+	serviceType string,
+	newResource bool,
+	key string,
+	userConfig map[string]interface{},
+	attributes []userConfigOneOfAttribute,
+	rawConfig cty.Value,
+) (interface{}, bool) {
+	var values []interface{}
+	omit := true
+
+	for _, attribute := range attributes {
+		name := encodeKeyName(attribute.name)
+		convertedValue, omitAttribute := convertTerraformUserConfigValueToAPICompatibleFormat(
+			serviceType, newResource, key, userConfig[name], attribute.definition, rawConfigAttribute(rawConfig, name))
+		if omitAttribute {
+			continue
+		}
+
+		omit = false
+		if list, ok := convertedValue.([]interface{}); ok {
+			values = append(values, list...)
+		}
+	}
+
+	if values == nil && !omit {
+		values = []interface{}{}
+	}
+
+	return values, omit
+}
+
 func convertTerraformUserConfigValueToAPICompatibleFormat(
 	serviceType string,
 	newResource bool,
 	key string,
 	value interface{},
 	definition map[string]interface{},
+	rawValue cty.Value,
 ) (interface{}, bool) {
 	var err error
 	var omit bool
@@ -323,6 +499,15 @@ func convertTerraformUserConfigValueToAPICompatibleFormat(
 		return nil, true
 	}
 
+	switch valueType {
+	case "integer", "number", "boolean", "string":
+		// typed options which are not set have a zero value, use the configuration to tell
+		// those apart from zero values which are set explicitly
+		if rawValue.IsNull() {
+			return nil, true
+		}
+	}
+
 	switch valueType {
 	case "integer":
 		convertedValue, err = convertTerraformUserConfigValueToAPICompatibleFormatInteger(value)
@@ -334,10 +519,10 @@ func convertTerraformUserConfigValueToAPICompatibleFormat(
 		convertedValue, err = convertTerraformUserConfigValueToAPICompatibleFormatString(value)
 	case "object":
 		convertedValue, omit, err = convertTerraformUserConfigValueToAPICompatibleFormatObject(
-			value, serviceType, newResource, definition)
+			value, serviceType, newResource, definition, rawValue)
 	case "array":
 		convertedValue, omit, err = convertTerraformUserConfigValueToAPICompatibleFormatArray(
-			value, serviceType, newResource, key, definition)
+			value, serviceType, newResource, key, definition, rawValue)
 	default:
 		err = fmt.Errorf("unsupported value type %v for %v user config key %v", definition["type"], serviceType, key)
 	}
@@ -357,20 +542,22 @@ func canOmit(value interface{}, definition map[string]interface{}) bool {
 		return true
 	}
 
-	// for backwards compatibility with the old versions omit when <<value not set>>
-	if value == "<<value not set>>" {
+	// omit <<value not set>>, used by the old versions and for the createOnly options of imported resources
+	if value == userConfigCreateOnlyUnknownValue {
 		return true
 	}
 
+	isMinusOne := value == "-1" || value == -1 || value == -1.0
+
 	// if minimum values can be lower then zero do not omit -1
-	if minimum, ok := definition["minimum"]; ok {
-		if math.Signbit(minimum.(float64)) && value == "-1" {
+	if minimum, ok := userConfigNumericKeyword(definition, "minimum"); ok {
+		if math.Signbit(minimum) && isMinusOne {
 			return false
 		}
 	}
 
 	// for backwards compatibility with the old versions omit when -1
-	if value == "-1" {
+	if isMinusOne {
 		return true
 	}
 
@@ -381,7 +568,8 @@ func convertTerraformUserConfigValueToAPICompatibleFormatArray(value interface{}
 	serviceType string,
 	newResource bool,
 	key string,
-	definition map[string]interface{}) (interface{}, bool, error) {
+	definition map[string]interface{},
+	rawValue cty.Value) (interface{}, bool, error) {
 	var convertedValue interface{}
 	omit := true
 
@@ -410,7 +598,7 @@ func convertTerraformUserConfigValueToAPICompatibleFormatArray(value interface{}
 
 		for idx, arrValue := range asArray {
 			arrValueConverted, _ := convertTerraformUserConfigValueToAPICompatibleFormat(
-				serviceType, newResource, key, arrValue, itemDefinition)
+				serviceType, newResource, key, arrValue, itemDefinition, rawConfigElement(rawValue, idx))
 			values[idx] = arrValueConverted
 		}
 
@@ -432,11 +620,78 @@ func selectFirstSchemaFromOneOf(itemDefinition map[string]interface{}) map[strin
 	return itemDefinition
 }
 
+// userConfigOneOfAttribute is a Terraform attribute of an array which items have oneOf alternatives,
+// the definition is the array definition with the items of a single alternative
+type userConfigOneOfAttribute struct {
+	name       string
+	definition map[string]interface{}
+}
+
+// userConfigOneOfAttributes returns the attributes of an array which items have more than one oneOf
+// alternative. The first alternative keeps the name of the array, the others are named after their
+// type, for example `namespaces_object`. Nil is returned for all the other definitions.
+func userConfigOneOfAttributes(key string, definition map[string]interface{}) []userConfigOneOfAttribute {
+	if getAivenSchemaType(definition["type"]) != "array" {
+		return nil
+	}
+
+	items, ok := definition["items"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	oneOf, ok := items["oneOf"].([]interface{})
+	if !ok || len(oneOf) < 2 {
+		return nil
+	}
+
+	attributes := make([]userConfigOneOfAttribute, len(oneOf))
+	names := make(map[string]bool, len(oneOf))
+	for i, alternativeRaw := range oneOf {
+		alternative := alternativeRaw.(map[string]interface{})
+
+		attributeDefinition := make(map[string]interface{}, len(definition))
+		for k, v := range definition {
+			attributeDefinition[k] = v
+		}
+		attributeDefinition["items"] = alternative
+
+		name := key
+		if i > 0 {
+			name = key + "_" + getAivenSchemaType(alternative["type"])
+			if names[name] {
+				name = fmt.Sprintf("%s_%d", name, i)
+			}
+			if title, ok := alternative["title"].(string); ok {
+				attributeDefinition["title"] = title
+			}
+		}
+		names[name] = true
+
+		attributes[i] = userConfigOneOfAttribute{name: name, definition: attributeDefinition}
+	}
+
+	return attributes
+}
+
+// matchUserConfigOneOfAttribute returns the index of the first oneOf attribute which items
+// have the type of the value, values matching none of them belong to the first attribute
+func matchUserConfigOneOfAttribute(value interface{}, attributes []userConfigOneOfAttribute) int {
+	for i, attribute := range attributes {
+		itemDefinition := attribute.definition["items"].(map[string]interface{})
+		if _, err := convertUserConfigJSONValue(getAivenSchemaType(itemDefinition["type"]), value); err == nil {
+			return i
+		}
+	}
+	return 0
+}
+
 func convertTerraformUserConfigValueToAPICompatibleFormatObject(
 	value interface{},
 	serviceType string,
 	newResource bool,
-	definition map[string]interface{}) (interface{}, bool, error) {
+	definition map[string]interface{},
+	rawValue cty.Value) (interface{}, bool, error) {
 	var convertedValue interface{}
 
 	// when value is nil
@@ -456,6 +711,7 @@ func convertTerraformUserConfigValueToAPICompatibleFormatObject(
 			} else {
 				convertedValue = convertTerraformUserConfigToAPICompatibleFormat(
 					serviceType, newResource, asMap, definition["properties"].(map[string]interface{}),
+					rawConfigElement(rawValue, 0),
 				)
 			}
 		}
@@ -466,7 +722,7 @@ func convertTerraformUserConfigValueToAPICompatibleFormatObject(
 	// when value is TypeMap
 	if asMap, isMap := value.(map[string]interface{}); isMap {
 		convertedValue = convertTerraformUserConfigToAPICompatibleFormat(
-			serviceType, newResource, asMap, definition["properties"].(map[string]interface{}),
+			serviceType, newResource, asMap, definition["properties"].(map[string]interface{}), rawValue,
 		)
 
 		return convertedValue, false, nil
@@ -553,3 +809,55 @@ func encodeKeyName(key string) string {
 func decodeKeyName(key string) string {
 	return strings.Replace(key, "__dot__", ".", -1)
 }
+
+// resourceRawConfig returns the raw configuration of a resource or an unknown value when
+// the configuration is not available
+func resourceRawConfig(d *schema.ResourceData) cty.Value {
+	if c := d.GetRawConfig(); !c.IsNull() {
+		return c
+	}
+	return cty.DynamicVal
+}
+
+// rawConfigValue returns a value of the raw configuration by its flatmap key, for example
+// "pg_user_config.0.pg.0.max_connections". The value is unknown when it cannot be determined,
+// null values are options which are not set in the configuration.
+func rawConfigValue(v cty.Value, k string) cty.Value {
+	for _, part := range strings.Split(k, ".") {
+		if index, err := strconv.Atoi(part); err == nil {
+			v = rawConfigElement(v, index)
+		} else {
+			v = rawConfigAttribute(v, part)
+		}
+	}
+	return v
+}
+
+func rawConfigAttribute(v cty.Value, name string) cty.Value {
+	switch {
+	case !v.IsKnown():
+		return cty.DynamicVal
+	case v.IsNull():
+		return cty.NullVal(cty.DynamicPseudoType)
+	case v.Type().IsObjectType() && v.Type().HasAttribute(name):
+		return v.GetAttr(name)
+	default:
+		return cty.DynamicVal
+	}
+}
+
+func rawConfigElement(v cty.Value, index int) cty.Value {
+	switch {
+	case !v.IsKnown():
+		return cty.DynamicVal
+	case v.IsNull():
+		return cty.NullVal(cty.DynamicPseudoType)
+	case v.Type().IsListType() || v.Type().IsTupleType():
+		if index >= v.LengthInt() {
+			return cty.NullVal(cty.DynamicPseudoType)
+		}
+		return v.Index(cty.NumberIntVal(int64(index)))
+	default:
+		return cty.DynamicVal
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// userConfigCreateOnlyUnknownValue marks the createOnly options of an imported resource, the API does
+// not return their values so they are not known
+const userConfigCreateOnlyUnknownValue = "<<value not set>>"
+
+// userConfigCreateOnlyDiffSuppressFunc suppresses the diff of user config options that can only be
+// set when the resource is created when the option is removed from the configuration, or when its
+// value is not known because the resource is imported. Options which are added or changed are
+// handled by customizeDiffUserConfigCreateOnly.
+func userConfigCreateOnlyDiffSuppressFunc(_, old, new string, d *schema.ResourceData) bool {
+	return len(d.Id()) > 0 && (new == "" || old == userConfigCreateOnlyUnknownValue)
+}
+
+// customizeDiffUserConfigCreateOnly fails the plan when a user config option that can only be set
+// when the resource is created is changed, the resource is replaced instead when the
+// `replace_on_create_only_changes` attribute is set
+func customizeDiffUserConfigCreateOnly(configType string, entryTypes ...string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+
+		var changed []string
+		for _, entryType := range entryTypes {
+			definition, ok := templates.GetUserConfigSchema(configType)[entryType].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			prefix := entryType + "_user_config"
+			for _, k := range d.GetChangedKeysPrefix(prefix) {
+				if isUserConfigCreateOnlyKey(strings.TrimPrefix(k, prefix+".0."), definition) {
+					changed = append(changed, k)
+				}
+			}
+
+			jsonKey := entryType + "_user_config_json"
+			if d.HasChange(jsonKey) {
+				old, new := d.GetChange(jsonKey)
+				if options := changedUserConfigJSONCreateOnlyOptions(old.(string), new.(string), definition); len(options) > 0 {
+					changed = append(changed, jsonKey)
+				}
+			}
+		}
+
+		if len(changed) == 0 {
+			return nil
+		}
+		sort.Strings(changed)
+
+		if replace, ok := d.Get("replace_on_create_only_changes").(bool); ok && replace {
+			for _, k := range changed {
+				if err := d.ForceNew(k); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		return fmt.Errorf("%s: the changed user config options can only be set when the %s is created, "+
+			"recreate the resource or set `replace_on_create_only_changes` to replace it automatically",
+			strings.Join(changed, ", "), configType)
+	}
+}
+
+// isUserConfigCreateOnlyKey checks if the option of a flatmap key, such as
+// "pg.0.max_connections", is flagged createOnly in the user config definition
+func isUserConfigCreateOnlyKey(k string, definition map[string]interface{}) bool {
+	for _, part := range strings.Split(k, ".") {
+		if _, err := strconv.Atoi(part); err == nil || part == "#" || part == "%" {
+			if items, ok := definition["items"].(map[string]interface{}); ok {
+				definition = selectFirstSchemaFromOneOf(items)
+			}
+			continue
+		}
+
+		properties, ok := definition["properties"].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if definition, ok = properties[decodeKeyName(part)].(map[string]interface{}); !ok {
+			return false
+		}
+		if createOnly, ok := definition["createOnly"].(bool); ok && createOnly {
+			return true
+		}
+	}
+	return false
+}
+
+// changedUserConfigJSONCreateOnlyOptions returns the createOnly options of a `<type>_user_config_json`
+// attribute which are added or changed, options which are removed do not need any changes
+func changedUserConfigJSONCreateOnlyOptions(old, new string, definition map[string]interface{}) []string {
+	var oldConfig, newConfig map[string]interface{}
+	if json.Unmarshal([]byte(old), &oldConfig) != nil || json.Unmarshal([]byte(new), &newConfig) != nil {
+		return nil
+	}
+
+	properties, _ := definition["properties"].(map[string]interface{})
+
+	var options []string
+	for k, v := range newConfig {
+		propertyDefinition, ok := properties[k].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if createOnly, ok := propertyDefinition["createOnly"].(bool); !ok || !createOnly {
+			continue
+		}
+		if v != nil && !reflect.DeepEqual(oldConfig[k], v) {
+			options = append(options, k)
+		}
+	}
+
+	sort.Strings(options)
+	return options
+}
+
+// restoreUserConfigCreateOnlyValues keeps the values of the createOnly options of the current state
+// when the API does not return them, so that later changes to the options can be detected
+func restoreUserConfigCreateOnlyValues(converted []map[string]interface{}, current interface{}, definition map[string]interface{}) {
+	currentList, _ := current.([]interface{})
+	properties, _ := definition["properties"].(map[string]interface{})
+
+	for i, c := range converted {
+		if i >= len(currentList) {
+			return
+		}
+		currentMap, ok := currentList[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for k, v := range c {
+			propertyDefinition, ok := properties[decodeKeyName(k)].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if nested, ok := v.([]map[string]interface{}); ok {
+				restoreUserConfigCreateOnlyValues(nested, currentMap[k], propertyDefinition)
+				continue
+			}
+
+			if createOnly, ok := propertyDefinition["createOnly"].(bool); !ok || !createOnly {
+				continue
+			}
+			if s, ok := currentMap[k].(string); ok && s != "" && v == "" {
+				c[k] = s
+			}
+		}
+	}
+}
+
+// restoreUserConfigJSONCreateOnlyValues returns a copy of the user configuration returned by the API
+// with the values of the createOnly options of the configured `<type>_user_config_json` attribute,
+// the API does not return them
+func restoreUserConfigJSONCreateOnlyValues(userConfig map[string]interface{}, configuredJSON string, definition map[string]interface{}) map[string]interface{} {
+	var configured map[string]interface{}
+	if json.Unmarshal([]byte(configuredJSON), &configured) != nil {
+		return userConfig
+	}
+
+	properties, _ := definition["properties"].(map[string]interface{})
+
+	result := make(map[string]interface{}, len(userConfig))
+	for k, v := range userConfig {
+		result[k] = v
+	}
+	for k, v := range configured {
+		propertyDefinition, ok := properties[k].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if createOnly, ok := propertyDefinition["createOnly"].(bool); !ok || !createOnly {
+			continue
+		}
+		if result[k] == nil {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+// markUserConfigCreateOnlyValuesUnknown marks the createOnly options which are not returned by the API
+// when a resource is imported, so that the options of the configuration are not taken as changes
+func markUserConfigCreateOnlyValuesUnknown(userConfig interface{}, definition map[string]interface{}) {
+	list, _ := userConfig.([]interface{})
+	properties, _ := definition["properties"].(map[string]interface{})
+
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for k, v := range m {
+			propertyDefinition, ok := properties[decodeKeyName(k)].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if nested, ok := v.([]interface{}); ok {
+				markUserConfigCreateOnlyValuesUnknown(nested, propertyDefinition)
+				continue
+			}
+
+			if createOnly, ok := propertyDefinition["createOnly"].(bool); !ok || !createOnly {
+				continue
+			}
+			if v == "" {
+				m[k] = userConfigCreateOnlyUnknownValue
+			}
+		}
+	}
+}
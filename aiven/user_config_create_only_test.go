@@ -0,0 +1,128 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_customizeDiffUserConfigCreateOnly(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"pg_user_config":                 generateServiceUserConfiguration(ServiceTypePG),
+			"pg_user_config_json":            generateServiceUserConfigurationJSON(ServiceTypePG),
+			"replace_on_create_only_changes": {Type: schema.TypeBool, Optional: true},
+		},
+		CustomizeDiff: customizeDiffUserConfigCreateOnly("service", ServiceTypePG),
+	}
+
+	state := &terraform.InstanceState{
+		ID: "project/service",
+		Attributes: map[string]string{
+			"pg_user_config.#":                      "1",
+			"pg_user_config.0.recovery_target_time": "2021-01-01 00:00:00",
+			"pg_user_config.0.admin_username":       "",
+		},
+	}
+
+	diff := func(userConfig map[string]interface{}, replace bool) (*terraform.InstanceDiff, error) {
+		return r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"pg_user_config":                 []interface{}{userConfig},
+			"replace_on_create_only_changes": replace,
+		}), nil)
+	}
+
+	// options that are removed are not changes
+	d, err := diff(map[string]interface{}{}, false)
+	assert.NoError(t, err)
+	assert.False(t, d != nil && d.RequiresNew())
+
+	// options that are added to an existing resource are changes
+	_, err = diff(map[string]interface{}{"recovery_target_time": "2021-01-01 00:00:00", "admin_username": "admin"}, false)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "pg_user_config.0.admin_username")
+	}
+
+	d, err = diff(map[string]interface{}{"recovery_target_time": "2021-01-01 00:00:00", "admin_username": "admin"}, true)
+	if assert.NoError(t, err) {
+		assert.True(t, d.RequiresNew())
+	}
+
+	_, err = diff(map[string]interface{}{"recovery_target_time": "2021-02-01 00:00:00"}, false)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "pg_user_config.0.recovery_target_time")
+	}
+
+	d, err = diff(map[string]interface{}{"recovery_target_time": "2021-02-01 00:00:00"}, true)
+	if assert.NoError(t, err) {
+		assert.True(t, d.RequiresNew())
+	}
+
+	// the values of imported resources are not known
+	state.Attributes["pg_user_config.0.admin_username"] = userConfigCreateOnlyUnknownValue
+	d, err = diff(map[string]interface{}{"recovery_target_time": "2021-01-01 00:00:00", "admin_username": "admin"}, false)
+	assert.NoError(t, err)
+	assert.False(t, d != nil && d.RequiresNew())
+}
+
+func Test_changedUserConfigJSONCreateOnlyOptions(t *testing.T) {
+	definition := userConfigTestDefinition("service", "pg")
+
+	assert.Equal(t, []string{"recovery_target_time", "service_to_fork_from"}, changedUserConfigJSONCreateOnlyOptions(
+		`{"service_to_fork_from": "a", "admin_username": "admin", "pg": {"jit": true}}`,
+		`{"service_to_fork_from": "b", "recovery_target_time": "2021-01-01 00:00:00", "pg": {"jit": false}}`,
+		definition,
+	))
+
+	assert.Equal(t, []string{"admin_username", "service_to_fork_from"}, changedUserConfigJSONCreateOnlyOptions(
+		`{"pg": {"jit": true}}`,
+		`{"service_to_fork_from": "a", "admin_username": "admin", "pg": {"jit": true}}`,
+		definition,
+	))
+	assert.Empty(t, changedUserConfigJSONCreateOnlyOptions(
+		`{"service_to_fork_from": "a", "admin_username": "admin"}`,
+		`{"pg": {"jit": true}}`,
+		definition,
+	))
+}
+
+func Test_restoreUserConfigCreateOnlyValues(t *testing.T) {
+	converted := ConvertAPIUserConfigToTerraformCompatibleFormat("service", "pg", map[string]interface{}{
+		"pg_version": "13",
+	})
+	restoreUserConfigCreateOnlyValues(converted, []interface{}{map[string]interface{}{
+		"service_to_fork_from": "source",
+		"pg_version":           "12",
+	}}, userConfigTestDefinition("service", "pg"))
+
+	assert.Equal(t, "source", converted[0]["service_to_fork_from"])
+	assert.Equal(t, "13", converted[0]["pg_version"])
+}
+
+func Test_restoreUserConfigJSONCreateOnlyValues(t *testing.T) {
+	definition := userConfigTestDefinition("service", "pg")
+	userConfig := map[string]interface{}{"pg_version": "13"}
+
+	got := restoreUserConfigJSONCreateOnlyValues(userConfig,
+		`{"service_to_fork_from": "source", "pg_version": "12", "pg": {"jit": true}}`, definition)
+	assert.Equal(t, map[string]interface{}{"service_to_fork_from": "source", "pg_version": "13"}, got)
+	assert.Equal(t, map[string]interface{}{"pg_version": "13"}, userConfig)
+}
+
+func Test_markUserConfigCreateOnlyValuesUnknown(t *testing.T) {
+	userConfig := []interface{}{map[string]interface{}{
+		"admin_username":       "",
+		"service_to_fork_from": "source",
+		"pg_version":           "",
+	}}
+	markUserConfigCreateOnlyValuesUnknown(userConfig, userConfigTestDefinition("service", "pg"))
+
+	m := userConfig[0].(map[string]interface{})
+	assert.Equal(t, userConfigCreateOnlyUnknownValue, m["admin_username"])
+	assert.Equal(t, "source", m["service_to_fork_from"])
+	assert.Equal(t, "", m["pg_version"])
+}
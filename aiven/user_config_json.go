@@ -0,0 +1,421 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// generateUserConfigurationJSON creates the `<type>_user_config_json` attribute, an alternative
+// to the `<type>_user_config` block which accepts the user configuration as a JSON encoded object
+func generateUserConfigurationJSON(configType, entryType, description string) *schema.Schema {
+	definition, _ := templates.GetUserConfigSchema(configType)[entryType].(map[string]interface{})
+
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Sensitive:        hasUserConfigSensitiveOptions(definition),
+		ConflictsWith:    []string{entryType + "_user_config"},
+		ValidateFunc:     validateUserConfigJSONObject,
+		StateFunc:        normalizeJsonString,
+		DiffSuppressFunc: diffSuppressJsonObject,
+		Description: fmt.Sprintf("%s as a JSON encoded object, an alternative to the `%s_user_config` block. "+
+			"Only the options that are set are compared with the actual configuration of the %s.",
+			description, entryType, configType),
+	}
+}
+
+// generateServiceUserConfigurationJSON generate service user_config_json
+func generateServiceUserConfigurationJSON(t string) *schema.Schema {
+	return generateUserConfigurationJSON("service", t, fmt.Sprintf("%s user configurable settings", strings.Title(t)))
+}
+
+// isUserConfigJSONUsed checks if the user configuration is managed with the `<type>_user_config_json`
+// attribute instead of the `<type>_user_config` block
+func isUserConfigJSONUsed(d *schema.ResourceData, entryType string) bool {
+	s, ok := d.Get(entryType + "_user_config_json").(string)
+	return ok && s != ""
+}
+
+// hasUserConfigJSON checks if the resource schema has the `<type>_user_config_json` attribute,
+// ResourceData returns nil for attributes which are not part of the schema
+func hasUserConfigJSON(d *schema.ResourceData, entryType string) bool {
+	_, ok := d.Get(entryType + "_user_config_json").(string)
+	return ok
+}
+
+// setUserConfigJSON sets the value of the `<type>_user_config_json` attribute when the user configuration
+// is managed with it, otherwise the `<type>_user_config` block is set with the given function
+func setUserConfigJSON(d *schema.ResourceData, entryType string, userConfig map[string]interface{}, setBlock func() error) error {
+	if !isUserConfigJSONUsed(d, entryType) {
+		return setBlock()
+	}
+
+	userConfigJSON, err := flattenUserConfigJSON(d.Get(entryType+"_user_config_json").(string), userConfig)
+	if err != nil {
+		return fmt.Errorf("cannot flatten `%s_user_config_json`: %w", entryType, err)
+	}
+	if err := d.Set(entryType+"_user_config_json", userConfigJSON); err != nil {
+		return err
+	}
+
+	return d.Set(entryType+"_user_config", []map[string]interface{}{})
+}
+
+// setDatasourceUserConfigJSON sets the complete user configuration returned by the API to the
+// `<type>_user_config_json` attribute of a data source, the values of sensitive options are masked
+func setDatasourceUserConfigJSON(d *schema.ResourceData, configType, entryType string, userConfig map[string]interface{}) error {
+	if !hasUserConfigJSON(d, entryType) {
+		return nil
+	}
+
+	if userConfig == nil {
+		userConfig = map[string]interface{}{}
+	}
+
+	definition, _ := templates.GetUserConfigSchema(configType)[entryType].(map[string]interface{})
+	b, err := json.Marshal(maskUserConfigSensitiveValues(userConfig, definition))
+	if err != nil {
+		return err
+	}
+
+	return d.Set(entryType+"_user_config_json", normalizeJsonString(string(b)))
+}
+
+// convertTerraformUserConfigJSONToAPICompatibleFormat decodes the `<type>_user_config_json` attribute,
+// the options that can only be set when the resource is created are dropped on updates
+func convertTerraformUserConfigJSONToAPICompatibleFormat(
+	configType string,
+	entryType string,
+	newResource bool,
+	userConfigJSON string,
+) (map[string]interface{}, error) {
+	var userConfig map[string]interface{}
+	if err := json.Unmarshal([]byte(userConfigJSON), &userConfig); err != nil {
+		return nil, fmt.Errorf("cannot decode %s_user_config_json: %w", entryType, err)
+	}
+
+	if !newResource {
+		if entrySchema, ok := templates.GetUserConfigSchema(configType)[entryType].(map[string]interface{}); ok {
+			removeUserConfigCreateOnlyOptions(userConfig, entrySchema)
+		}
+	}
+
+	return userConfig, nil
+}
+
+func removeUserConfigCreateOnlyOptions(userConfig map[string]interface{}, definition map[string]interface{}) {
+	properties, ok := definition["properties"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for key, value := range userConfig {
+		propertyDefinition, ok := properties[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if createOnly, ok := propertyDefinition["createOnly"].(bool); ok && createOnly {
+			delete(userConfig, key)
+			continue
+		}
+
+		if nested, ok := value.(map[string]interface{}); ok {
+			removeUserConfigCreateOnlyOptions(nested, propertyDefinition)
+		}
+	}
+}
+
+// flattenUserConfigJSON converts the user configuration returned by the API to the value of the
+// `<type>_user_config_json` attribute. Only the options present in the configured JSON are kept,
+// otherwise all the defaults filled in by the API would show up as a diff. Lists of scalar values
+// that only differ in the order of items, such as IP filters sorted by the API, keep the configured
+// order.
+func flattenUserConfigJSON(configuredJSON string, userConfig map[string]interface{}) (string, error) {
+	var configured map[string]interface{}
+	if err := json.Unmarshal([]byte(configuredJSON), &configured); err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(filterUserConfigJSONValue(configured, userConfig))
+	if err != nil {
+		return "", err
+	}
+
+	return normalizeJsonString(string(b)), nil
+}
+
+func filterUserConfigJSONValue(configured, actual interface{}) interface{} {
+	switch c := configured.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return actual
+		}
+
+		result := make(map[string]interface{})
+		for k, v := range c {
+			if actualValue, ok := a[k]; ok {
+				result[k] = filterUserConfigJSONValue(v, actualValue)
+			}
+		}
+		return result
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			return actual
+		}
+
+		if isSameUserConfigJSONList(c, a) {
+			return c
+		}
+
+		// lists of objects are compared item by item
+		result := make([]interface{}, len(a))
+		for i, v := range a {
+			if i < len(c) {
+				result[i] = filterUserConfigJSONValue(c[i], v)
+			} else {
+				result[i] = v
+			}
+		}
+		return result
+	default:
+		return actual
+	}
+}
+
+// isSameUserConfigJSONList checks if two lists of scalar values contain the same items
+func isSameUserConfigJSONList(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	sortedStrings := func(l []interface{}) []string {
+		result := make([]string, len(l))
+		for i, v := range l {
+			switch v.(type) {
+			case map[string]interface{}, []interface{}:
+				return nil
+			}
+			result[i] = fmt.Sprintf("%T:%v", v, v)
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	sortedA, sortedB := sortedStrings(a), sortedStrings(b)
+	return sortedA != nil && sortedB != nil && reflect.DeepEqual(sortedA, sortedB)
+}
+
+// validateUserConfigJSONObject is a ValidateFunc that ensures the value is a JSON encoded object,
+// the options are validated by customizeDiffUserConfigJSON as the schema can be fetched from the API
+func validateUserConfigJSONObject(i interface{}, k string) (ws []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	var userConfig map[string]interface{}
+	if err := json.Unmarshal([]byte(v), &userConfig); err != nil {
+		return nil, []error{fmt.Errorf("%q contains an invalid JSON object: %s", k, err)}
+	}
+
+	return nil, nil
+}
+
+// customizeDiffUserConfigJSON validates the `<type>_user_config_json` attributes of the given entry types
+// against the user config schemas of the provider, options released after the embedded schemas were
+// generated are accepted when the schemas are fetched from the API
+func customizeDiffUserConfigJSON(configType string, entryTypes ...string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+		for _, entryType := range entryTypes {
+			key := entryType + "_user_config_json"
+			if !d.NewValueKnown(key) {
+				continue
+			}
+
+			v, ok := d.Get(key).(string)
+			if !ok || v == "" {
+				continue
+			}
+
+			var project string
+			if d.NewValueKnown("project") {
+				project, _ = d.Get("project").(string)
+			}
+
+			definition, ok := getUserConfigSchemas(m).get(project, configType, entryType)
+			if !ok {
+				return fmt.Errorf("%q: unsupported %s user config type %s", key, configType, entryType)
+			}
+
+			if errs := validateUserConfigJSON(key, v, definition); len(errs) > 0 {
+				var messages []string
+				for _, err := range errs {
+					messages = append(messages, err.Error())
+				}
+				sort.Strings(messages)
+				return fmt.Errorf("%s", strings.Join(messages, "; "))
+			}
+		}
+
+		return nil
+	}
+}
+
+// userConfigEntryTypes returns the entry types of the `<type>_user_config` blocks of a schema
+func userConfigEntryTypes(s map[string]*schema.Schema) []string {
+	var entryTypes []string
+	for k := range s {
+		if strings.HasSuffix(k, "_user_config") {
+			entryTypes = append(entryTypes, strings.TrimSuffix(k, "_user_config"))
+		}
+	}
+	sort.Strings(entryTypes)
+	return entryTypes
+}
+
+// userConfigJSONEntryTypes returns the entry types of the `<type>_user_config_json` attributes of a schema
+func userConfigJSONEntryTypes(s map[string]*schema.Schema) []string {
+	var entryTypes []string
+	for k := range s {
+		if strings.HasSuffix(k, "_user_config_json") {
+			entryTypes = append(entryTypes, strings.TrimSuffix(k, "_user_config_json"))
+		}
+	}
+	sort.Strings(entryTypes)
+	return entryTypes
+}
+
+// validateUserConfigJSON validates a JSON encoded user configuration against its JSON schema
+func validateUserConfigJSON(k, v string, definition map[string]interface{}) []error {
+	var userConfig interface{}
+	if err := json.Unmarshal([]byte(v), &userConfig); err != nil {
+		return []error{fmt.Errorf("%q contains an invalid JSON: %s", k, err)}
+	}
+
+	return validateUserConfigJSONValue(k, userConfig, definition)
+}
+
+func validateUserConfigJSONValue(path string, value interface{}, definition map[string]interface{}) []error {
+	if value == nil {
+		if isUserConfigNullable(definition) {
+			return nil
+		}
+		return []error{fmt.Errorf("%q cannot be null", path)}
+	}
+
+	// oneOf is used for array items that can have different types, any of them is accepted
+	if oneOf, ok := definition["oneOf"].([]interface{}); ok && len(oneOf) > 0 {
+		var errs []error
+		for _, alternative := range oneOf {
+			errs = validateUserConfigJSONValue(path, value, alternative.(map[string]interface{}))
+			if len(errs) == 0 {
+				return nil
+			}
+		}
+		return errs
+	}
+
+	switch valueType := getAivenSchemaType(definition["type"]); valueType {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return []error{fmt.Errorf("expected %q to be an object, got %v", path, value)}
+		}
+
+		properties, _ := definition["properties"].(map[string]interface{})
+
+		var errs []error
+		for k, v := range object {
+			propertyDefinition, ok := properties[k].(map[string]interface{})
+			if !ok {
+				errs = append(errs, fmt.Errorf("%q: unsupported option %q", path, k))
+				continue
+			}
+			errs = append(errs, validateUserConfigJSONValue(path+"."+k, v, propertyDefinition)...)
+		}
+		return errs
+	case "array":
+		list, ok := value.([]interface{})
+		if !ok {
+			return []error{fmt.Errorf("expected %q to be a list, got %v", path, value)}
+		}
+
+		if maxItems, ok := userConfigNumericKeyword(definition, "maxItems"); ok && float64(len(list)) > maxItems {
+			return []error{fmt.Errorf("expected %q to have at most %v items, got %d", path, maxItems, len(list))}
+		}
+
+		itemDefinition, ok := definition["items"].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		var errs []error
+		for i, v := range list {
+			errs = append(errs, validateUserConfigJSONValue(fmt.Sprintf("%s[%d]", path, i), v, itemDefinition)...)
+		}
+		return errs
+	default:
+		v, err := convertUserConfigJSONValue(valueType, value)
+		if err != nil {
+			return []error{fmt.Errorf("expected %q to be %s, got %v", path, userConfigTypeDescription(valueType), value)}
+		}
+
+		if validateFunc := generateTerraformUserConfigValidateFunc(definition); validateFunc != nil {
+			_, errs := validateFunc(v, path)
+			return errs
+		}
+		return nil
+	}
+}
+
+// convertUserConfigJSONValue converts a decoded JSON value to the Go type used for
+// the JSON schema type, JSON numbers are always decoded as float64
+func convertUserConfigJSONValue(valueType string, value interface{}) (interface{}, error) {
+	switch valueType {
+	case "integer":
+		if n, ok := value.(float64); ok && n == math.Trunc(n) {
+			return int(n), nil
+		}
+	case "number":
+		if n, ok := value.(float64); ok {
+			return n, nil
+		}
+	case "boolean":
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	case "object":
+		if m, ok := value.(map[string]interface{}); ok {
+			return m, nil
+		}
+	default:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unexpected value %v", value)
+}
+
+func isUserConfigNullable(definition map[string]interface{}) bool {
+	if types, ok := definition["type"].([]interface{}); ok {
+		for _, t := range types {
+			if t == "null" {
+				return true
+			}
+		}
+	}
+	return false
+}
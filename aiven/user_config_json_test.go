@@ -0,0 +1,127 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateUserConfigJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		configType string
+		entryType  string
+		value      string
+		wantErr    string
+	}{
+		{
+			"valid",
+			"service",
+			"pg",
+			`{"ip_filter": ["10.0.0.0/8"], "pg": {"max_wal_senders": 20, "jit": true, "pg_partman_bgw.interval": 3600}}`,
+			"",
+		},
+		{
+			"invalid-json",
+			"service",
+			"pg",
+			`{"ip_filter": }`,
+			"invalid JSON",
+		},
+		{
+			"unsupported-option",
+			"service",
+			"pg",
+			`{"pg": {"max_connections": 100}}`,
+			`unsupported option "max_connections"`,
+		},
+		{
+			"integer-expected",
+			"service",
+			"pg",
+			`{"pg": {"max_wal_senders": 20.5}}`,
+			"to be an integer",
+		},
+		{
+			"out-of-range",
+			"service",
+			"kafka",
+			`{"kafka": {"message_max_bytes": 100001201}}`,
+			"to be in the range",
+		},
+		{
+			"nullable",
+			"service",
+			"pg",
+			`{"backup_hour": null}`,
+			"",
+		},
+		{
+			"too-many-items",
+			"integration",
+			"datadog",
+			`{"datadog_tags": [` + strings.TrimSuffix(strings.Repeat(`{"tag": "a"},`, 33), ",") + `]}`,
+			"to have at most",
+		},
+		{
+			"one-of",
+			"service",
+			"m3db",
+			`{"rules": {"mapping": [{"filter": "a:b", "namespaces": ["aggregated_*", {"resolution": "30s", "retention": "48h"}]}]}}`,
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			definition := templates.GetUserConfigSchema(tt.configType)[tt.entryType].(map[string]interface{})
+			errs := validateUserConfigJSON("key", tt.value, definition)
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+
+			if assert.NotEmpty(t, errs) {
+				assert.Contains(t, errs[0].Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_flattenUserConfigJSON(t *testing.T) {
+	got, err := flattenUserConfigJSON(
+		`{"ip_filter": ["10.0.0.0/8", "0.0.0.0/0"], "pg": {"max_wal_senders": 20}, "backup_hour": 3}`,
+		map[string]interface{}{
+			"ip_filter":       []interface{}{"0.0.0.0/0", "10.0.0.0/8"},
+			"pg":              map[string]interface{}{"max_wal_senders": float64(25), "jit": true},
+			"pg_version":      "13",
+			"pg_read_replica": false,
+		},
+	)
+	if assert.NoError(t, err) {
+		assert.Equal(t, `{"ip_filter":["10.0.0.0/8","0.0.0.0/0"],"pg":{"max_wal_senders":25}}`, got)
+	}
+}
+
+func Test_convertTerraformUserConfigJSONToAPICompatibleFormat(t *testing.T) {
+	userConfigJSON := `{"admin_username": "admin", "pg_version": "13", "pg": {"jit": true}}`
+
+	got, err := convertTerraformUserConfigJSONToAPICompatibleFormat("service", "pg", true, userConfigJSON)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{
+			"admin_username": "admin",
+			"pg_version":     "13",
+			"pg":             map[string]interface{}{"jit": true},
+		}, got)
+	}
+
+	got, err = convertTerraformUserConfigJSONToAPICompatibleFormat("service", "pg", false, userConfigJSON)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{
+			"pg_version": "13",
+			"pg":         map[string]interface{}{"jit": true},
+		}, got)
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// userConfigSchemas are the user config schemas used by a provider instance. When fetching is
+// enabled the service schemas are loaded from the API when the provider is configured, integration
+// and endpoint types are project specific and are loaded when a project needs them. The embedded
+// schemas are used for everything the API did not return.
+type userConfigSchemas struct {
+	client *aiven.Client
+	fetch  bool
+
+	mu       sync.Mutex
+	services map[string]interface{}
+	projects map[string]map[string]map[string]interface{}
+	// warned are the projects whose stale schema warning has been returned
+	warned map[string]bool
+}
+
+// newUserConfigSchemas creates the user config schemas of a provider instance, a warning is
+// returned when the schemas cannot be fetched or when the embedded schemas are out of date
+func newUserConfigSchemas(client *aiven.Client, fetch bool) (*userConfigSchemas, diag.Diagnostics) {
+	s := &userConfigSchemas{
+		client:   client,
+		fetch:    fetch,
+		projects: make(map[string]map[string]map[string]interface{}),
+		warned:   make(map[string]bool),
+	}
+
+	if !fetch {
+		return s, nil
+	}
+
+	serviceTypes, err := aivenapi.ListServiceTypes(client)
+	if err != nil {
+		return s, diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Cannot fetch the user config schemas from the API",
+			Detail:   fmt.Sprintf("The user config schemas embedded in the provider are used instead: %s", err),
+		}}
+	}
+
+	s.services = make(map[string]interface{}, len(serviceTypes))
+	for name, serviceType := range serviceTypes {
+		if serviceType.UserConfigSchema != nil {
+			s.services[name] = serviceType.UserConfigSchema
+		}
+	}
+
+	if options := newUserConfigOptions(templates.UserConfigSchemaService, s.services); len(options) > 0 {
+		return s, diag.Diagnostics{userConfigSchemasStaleWarning(options)}
+	}
+
+	return s, nil
+}
+
+// getUserConfigSchemas returns the user config schemas of the provider instance the meta
+// belongs to, only the embedded schemas are used when the provider was not configured
+func getUserConfigSchemas(m interface{}) *userConfigSchemas {
+	if meta, ok := m.(*providerMeta); ok && meta.userConfigSchemas != nil {
+		return meta.userConfigSchemas
+	}
+
+	return &userConfigSchemas{}
+}
+
+// get returns the user config schema of an entry type, the project is used to fetch the integration
+// and endpoint schemas and can be empty when it is not known yet
+func (s *userConfigSchemas) get(project, configType, entryType string) (map[string]interface{}, bool) {
+	var fetched map[string]interface{}
+	if s.fetch {
+		switch configType {
+		case templates.UserConfigSchemaService:
+			fetched = s.services
+		case templates.UserConfigSchemaIntegration, templates.UserConfigSchemaEndpoint:
+			if project != "" {
+				fetched = s.getProjectSchemas(project)[configType]
+			}
+		}
+	}
+
+	if definition, ok := fetched[entryType].(map[string]interface{}); ok {
+		return definition, true
+	}
+
+	definition, ok := templates.GetUserConfigSchema(configType)[entryType].(map[string]interface{})
+	return definition, ok
+}
+
+// getProjectSchemas returns the integration and endpoint schemas of a project, they are kept only
+// when both of them are fetched so that a failed fetch is retried by the next caller
+func (s *userConfigSchemas) getProjectSchemas(project string) map[string]map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if schemas, ok := s.projects[project]; ok {
+		return schemas
+	}
+
+	schemas := map[string]map[string]interface{}{
+		templates.UserConfigSchemaIntegration: {},
+		templates.UserConfigSchemaEndpoint:    {},
+	}
+
+	integrationTypes, integrationErr := aivenapi.ListIntegrationTypes(s.client, project)
+	if integrationErr != nil {
+		log.Printf("[WARN] cannot fetch the integration user config schemas of project %s, using the embedded ones: %s", project, integrationErr)
+	}
+	for _, t := range integrationTypes {
+		if t.UserConfigSchema != nil {
+			schemas[templates.UserConfigSchemaIntegration][t.IntegrationType] = t.UserConfigSchema
+		}
+	}
+
+	endpointTypes, endpointErr := aivenapi.ListIntegrationEndpointTypes(s.client, project)
+	if endpointErr != nil {
+		log.Printf("[WARN] cannot fetch the endpoint user config schemas of project %s, using the embedded ones: %s", project, endpointErr)
+	}
+	for _, t := range endpointTypes {
+		if t.UserConfigSchema != nil {
+			schemas[templates.UserConfigSchemaEndpoint][t.EndpointType] = t.UserConfigSchema
+		}
+	}
+
+	if integrationErr == nil && endpointErr == nil {
+		s.projects[project] = schemas
+	}
+
+	return schemas
+}
+
+// projectStaleWarning returns the warning about the embedded integration and endpoint schemas that
+// are out of date for a project, it is returned once per project instead of by every integration
+// and endpoint of the project
+func (s *userConfigSchemas) projectStaleWarning(project string) diag.Diagnostics {
+	if !s.fetch || project == "" {
+		return nil
+	}
+
+	schemas := s.getProjectSchemas(project)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, fetched := s.projects[project]; !fetched || s.warned[project] {
+		return nil
+	}
+	s.warned[project] = true
+
+	var options []string
+	for _, configType := range []string{templates.UserConfigSchemaIntegration, templates.UserConfigSchemaEndpoint} {
+		options = append(options, newUserConfigOptions(configType, schemas[configType])...)
+	}
+	sort.Strings(options)
+	if len(options) == 0 {
+		return nil
+	}
+
+	return diag.Diagnostics{userConfigSchemasStaleWarning(options)}
+}
+
+// newUserConfigOptions returns the options of the fetched schemas which are missing from the
+// embedded schemas of the same config type
+func newUserConfigOptions(configType string, fetched map[string]interface{}) []string {
+	embedded := templates.GetUserConfigSchema(configType)
+
+	var options []string
+	for entryType, definition := range fetched {
+		fetchedDefinition, ok := definition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		embeddedDefinition, ok := embedded[entryType].(map[string]interface{})
+		if !ok {
+			// the whole type is new, there are no attributes for it
+			continue
+		}
+
+		options = append(options, newUserConfigDefinitionOptions(entryType, embeddedDefinition, fetchedDefinition)...)
+	}
+
+	sort.Strings(options)
+	return options
+}
+
+func newUserConfigDefinitionOptions(path string, embedded, fetched map[string]interface{}) []string {
+	var options []string
+
+	if fetchedProperties, ok := fetched["properties"].(map[string]interface{}); ok {
+		embeddedProperties, _ := embedded["properties"].(map[string]interface{})
+		for k, v := range fetchedProperties {
+			embeddedProperty, ok := embeddedProperties[k].(map[string]interface{})
+			if !ok {
+				options = append(options, path+"."+k)
+				continue
+			}
+
+			if fetchedProperty, ok := v.(map[string]interface{}); ok {
+				options = append(options, newUserConfigDefinitionOptions(path+"."+k, embeddedProperty, fetchedProperty)...)
+			}
+		}
+	}
+
+	if fetchedItems, ok := fetched["items"].(map[string]interface{}); ok {
+		if embeddedItems, ok := embedded["items"].(map[string]interface{}); ok {
+			options = append(options, newUserConfigDefinitionOptions(path+"[]", embeddedItems, fetchedItems)...)
+		}
+	}
+
+	return options
+}
+
+func userConfigSchemasStaleWarning(options []string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "The user config schemas embedded in the provider are out of date",
+		Detail: fmt.Sprintf("The following options are not supported by the `<type>_user_config` blocks and can only "+
+			"be set with the `<type>_user_config_json` attributes: %s. Upgrade the provider to manage them with the blocks.",
+			strings.Join(options, ", ")),
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"testing"
+
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/stretchr/testify/assert"
+)
+
+// fetchedPGUserConfigSchema returns the embedded pg schema with an additional option, as if
+// the option was released after the provider
+func fetchedPGUserConfigSchema() map[string]interface{} {
+	return fetchedUserConfigSchema("service", "pg")
+}
+
+// fetchedUserConfigSchema returns an embedded schema with an additional option, as if the option
+// was released after the provider
+func fetchedUserConfigSchema(configType, entryType string) map[string]interface{} {
+	embedded := templates.GetUserConfigSchema(configType)[entryType].(map[string]interface{})
+	properties := make(map[string]interface{})
+	for k, v := range embedded["properties"].(map[string]interface{}) {
+		properties[k] = v
+	}
+	properties["new_option"] = map[string]interface{}{"type": "integer", "minimum": 1}
+
+	fetched := make(map[string]interface{})
+	for k, v := range embedded {
+		fetched[k] = v
+	}
+	fetched["properties"] = properties
+	return fetched
+}
+
+func Test_newUserConfigOptions(t *testing.T) {
+	assert.Equal(t, []string{"pg.new_option"}, newUserConfigOptions("service", map[string]interface{}{
+		"pg":          fetchedPGUserConfigSchema(),
+		"new_service": map[string]interface{}{"type": "object"},
+	}))
+	assert.Empty(t, newUserConfigOptions("service", templates.GetUserConfigSchema("service")))
+}
+
+func Test_userConfigSchemas_get(t *testing.T) {
+	userConfig := `{"pg": {"jit": true}, "new_option": 10}`
+
+	embedded, ok := getUserConfigSchemas(nil).get("", "service", "pg")
+	if assert.True(t, ok) {
+		errs := validateUserConfigJSON("pg_user_config_json", userConfig, embedded)
+		if assert.Len(t, errs, 1) {
+			assert.Contains(t, errs[0].Error(), `unsupported option "new_option"`)
+		}
+	}
+
+	s := &userConfigSchemas{fetch: true, services: map[string]interface{}{"pg": fetchedPGUserConfigSchema()}}
+	fetched, ok := s.get("", "service", "pg")
+	if assert.True(t, ok) {
+		assert.Empty(t, validateUserConfigJSON("pg_user_config_json", userConfig, fetched))
+	}
+
+	// types missing from the API response fall back to the embedded schemas
+	_, ok = s.get("", "service", "mysql")
+	assert.True(t, ok)
+	_, ok = s.get("", "service", "unknown")
+	assert.False(t, ok)
+}
+
+func Test_userConfigSchemas_projectStaleWarning(t *testing.T) {
+	s := &userConfigSchemas{
+		fetch: true,
+		projects: map[string]map[string]map[string]interface{}{
+			"project": {
+				"integration": {"metrics": fetchedUserConfigSchema("integration", "metrics")},
+				"endpoint":    {},
+			},
+		},
+		warned: make(map[string]bool),
+	}
+
+	diags := s.projectStaleWarning("project")
+	if assert.Len(t, diags, 1) {
+		assert.Contains(t, diags[0].Detail, "metrics.new_option")
+	}
+	assert.Empty(t, s.projectStaleWarning("project"), "the warning should be returned once per project")
+
+	assert.Empty(t, (&userConfigSchemas{}).projectStaleWarning("project"), "the embedded schemas are not stale when fetching is disabled")
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+// userConfigSensitiveOptions are the names of the user config options which contain secrets. Options are
+// also sensitive when their JSON schema has the writeOnly keyword or the password format. Keep the list
+// in sync with the embedded schemas, Test_userConfigSensitiveOptions fails for unlisted secret-looking
+// options.
+var userConfigSensitiveOptions = map[string]bool{
+	"access_key":                  true,
+	"admin_password":              true,
+	"basic_auth_password":         true,
+	"client_secret":               true,
+	"datadog_api_key":             true,
+	"key":                         true,
+	"password":                    true,
+	"sasl_plain_password":         true,
+	"secret_key":                  true,
+	"service_account_credentials": true,
+	"signalfx_api_key":            true,
+	"ssl_client_key":              true,
+}
+
+// userConfigSensitiveMask replaces the values of sensitive options which are not known from the state
+const userConfigSensitiveMask = "<sensitive>"
+
+// isUserConfigSensitive checks if a user config option contains a secret
+func isUserConfigSensitive(key string, definition map[string]interface{}) bool {
+	if userConfigSensitiveOptions[decodeKeyName(key)] {
+		return true
+	}
+
+	if writeOnly, ok := definition["writeOnly"].(bool); ok && writeOnly {
+		return true
+	}
+
+	return definition["format"] == "password"
+}
+
+// hasUserConfigSensitiveOptions checks if any of the options of a user config definition is sensitive
+func hasUserConfigSensitiveOptions(definition map[string]interface{}) bool {
+	if properties, ok := definition["properties"].(map[string]interface{}); ok {
+		for k, v := range properties {
+			propertyDefinition, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if isUserConfigSensitive(k, propertyDefinition) || hasUserConfigSensitiveOptions(propertyDefinition) {
+				return true
+			}
+		}
+	}
+
+	if items, ok := definition["items"].(map[string]interface{}); ok && hasUserConfigSensitiveOptions(items) {
+		return true
+	}
+
+	if oneOf, ok := definition["oneOf"].([]interface{}); ok {
+		for _, alternative := range oneOf {
+			if alternativeDefinition, ok := alternative.(map[string]interface{}); ok && hasUserConfigSensitiveOptions(alternativeDefinition) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// maskUserConfigSensitiveValue returns the value of a user config option with the mask when the
+// option is sensitive. Nested attributes of data sources and `<type>_user_config_json` attributes
+// cannot be marked as sensitive, the mask keeps the secrets out of the plan output.
+func maskUserConfigSensitiveValue(key string, definition map[string]interface{}, value interface{}) interface{} {
+	if s, ok := value.(string); ok && s != "" && isUserConfigSensitive(key, definition) {
+		return userConfigSensitiveMask
+	}
+	return value
+}
+
+// maskUserConfigSensitiveValues masks the sensitive options of a user config in the API format
+func maskUserConfigSensitiveValues(userConfig interface{}, definition map[string]interface{}) interface{} {
+	switch v := userConfig.(type) {
+	case map[string]interface{}:
+		properties, _ := definition["properties"].(map[string]interface{})
+
+		result := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			propertyDefinition, ok := properties[key].(map[string]interface{})
+			if !ok {
+				result[key] = value
+				continue
+			}
+			result[key] = maskUserConfigSensitiveValues(maskUserConfigSensitiveValue(key, propertyDefinition, value), propertyDefinition)
+		}
+		return result
+	case []interface{}:
+		items, _ := definition["items"].(map[string]interface{})
+
+		result := make([]interface{}, len(v))
+		for i, value := range v {
+			// the alternatives of oneOf items are tried in order
+			itemDefinition := items
+			if oneOf, ok := items["oneOf"].([]interface{}); ok {
+				for _, alternative := range oneOf {
+					alternativeDefinition := alternative.(map[string]interface{})
+					if _, err := convertUserConfigJSONValue(getAivenSchemaType(alternativeDefinition["type"]), value); err == nil {
+						itemDefinition = alternativeDefinition
+						break
+					}
+				}
+			}
+			result[i] = maskUserConfigSensitiveValues(value, itemDefinition)
+		}
+		return result
+	default:
+		return userConfig
+	}
+}
+
+// restoreUserConfigSensitiveValues replaces the masked values of a user config converted with
+// ConvertAPIUserConfigToTerraformCompatibleFormat with the values of the current state, so that the
+// secrets of resources do not show up as changes
+func restoreUserConfigSensitiveValues(converted, current interface{}) {
+	switch c := converted.(type) {
+	case []map[string]interface{}:
+		currentList, _ := current.([]interface{})
+		for i, v := range c {
+			if i < len(currentList) {
+				restoreUserConfigSensitiveValues(v, currentList[i])
+			}
+		}
+	case []interface{}:
+		currentList, _ := current.([]interface{})
+		for i, v := range c {
+			if i >= len(currentList) {
+				continue
+			}
+			if v == userConfigSensitiveMask {
+				if s, ok := currentList[i].(string); ok && s != "" {
+					c[i] = s
+				}
+				continue
+			}
+			restoreUserConfigSensitiveValues(v, currentList[i])
+		}
+	case map[string]interface{}:
+		currentMap, _ := current.(map[string]interface{})
+		for k, v := range c {
+			if v == userConfigSensitiveMask {
+				if s, ok := currentMap[k].(string); ok && s != "" {
+					c[k] = s
+				}
+				continue
+			}
+			restoreUserConfigSensitiveValues(v, currentMap[k])
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_userConfigSensitiveOptions walks all the embedded schemas and fails when an option that looks
+// like a secret is not sensitive, options which only look like secrets are listed as reviewed
+func Test_userConfigSensitiveOptions(t *testing.T) {
+	secretLooking := regexp.MustCompile(`password|secret|api_key|access_key|private_key|credential|token|(^|_)key$`)
+	reviewed := map[string]bool{
+		"innodb_ft_min_token_size": true,
+		"sql_require_primary_key":  true,
+		"token_url":                true,
+	}
+
+	var walk func(path string, definition map[string]interface{})
+	walk = func(path string, definition map[string]interface{}) {
+		if properties, ok := definition["properties"].(map[string]interface{}); ok {
+			for k, v := range properties {
+				propertyDefinition := v.(map[string]interface{})
+				if secretLooking.MatchString(k) && !reviewed[k] {
+					assert.True(t, isUserConfigSensitive(k, propertyDefinition), "option %s.%s is not sensitive", path, k)
+				}
+				walk(path+"."+k, propertyDefinition)
+			}
+		}
+		if items, ok := definition["items"].(map[string]interface{}); ok {
+			walk(path+"[]", items)
+		}
+		if oneOf, ok := definition["oneOf"].([]interface{}); ok {
+			for _, v := range oneOf {
+				walk(path, v.(map[string]interface{}))
+			}
+		}
+	}
+
+	for _, configType := range []string{
+		templates.UserConfigSchemaService,
+		templates.UserConfigSchemaIntegration,
+		templates.UserConfigSchemaEndpoint,
+	} {
+		for entryType, definition := range templates.GetUserConfigSchema(configType) {
+			walk(configType+"."+entryType, definition.(map[string]interface{}))
+		}
+	}
+
+	assert.True(t, isUserConfigSensitive("token", map[string]interface{}{"type": "string", "writeOnly": true}))
+	assert.True(t, isUserConfigSensitive("token", map[string]interface{}{"type": "string", "format": "password"}))
+}
+
+func Test_userConfigSensitiveValues(t *testing.T) {
+	got := ConvertAPIUserConfigToTerraformCompatibleFormat("endpoint", "external_kafka", map[string]interface{}{
+		"bootstrap_servers":   "kafka:9092",
+		"security_protocol":   "SASL_SSL",
+		"sasl_plain_username": "user",
+		"sasl_plain_password": "secret",
+		"ssl_client_key":      "",
+	})
+	assert.Equal(t, userConfigSensitiveMask, got[0]["sasl_plain_password"])
+	assert.Equal(t, "", got[0]["ssl_client_key"])
+	assert.Equal(t, "user", got[0]["sasl_plain_username"])
+
+	restoreUserConfigSensitiveValues(got, []interface{}{map[string]interface{}{"sasl_plain_password": "secret"}})
+	assert.Equal(t, "secret", got[0]["sasl_plain_password"])
+
+	definition := templates.GetUserConfigSchema("service")["grafana"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"auth_github": map[string]interface{}{"client_id": "id", "client_secret": userConfigSensitiveMask},
+		"ip_filter":   []interface{}{"0.0.0.0/0"},
+	}, maskUserConfigSensitiveValues(map[string]interface{}{
+		"auth_github": map[string]interface{}{"client_id": "id", "client_secret": "secret"},
+		"ip_filter":   []interface{}{"0.0.0.0/0"},
+	}, definition))
+}
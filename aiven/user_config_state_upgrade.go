@@ -0,0 +1,155 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// userConfigStateUpgraders returns the state upgraders of a resource with user configuration
+// options. In schema version 0 all the integer, number and boolean options were stored as strings.
+func userConfigStateUpgraders(configType string, s map[string]*schema.Schema) []schema.StateUpgrader {
+	return []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    (&schema.Resource{Schema: userConfigSchemaV0(s)}).CoreConfigSchema().ImpliedType(),
+			Upgrade: userConfigStateUpgradeV0(configType),
+		},
+	}
+}
+
+// userConfigSchemaV0 returns a copy of the resource schema where all the user configuration
+// options are strings, as they were in schema version 0
+func userConfigSchemaV0(s map[string]*schema.Schema) map[string]*schema.Schema {
+	result := make(map[string]*schema.Schema, len(s))
+	for k, v := range s {
+		if strings.HasSuffix(k, "_user_config") {
+			v = userConfigOptionSchemaV0(v)
+		}
+		result[k] = v
+	}
+	return result
+}
+
+func userConfigOptionSchemaV0(s *schema.Schema) *schema.Schema {
+	result := *s
+	switch result.Type {
+	case schema.TypeInt, schema.TypeFloat, schema.TypeBool:
+		result.Type = schema.TypeString
+		result.ValidateFunc = nil
+	}
+
+	switch elem := result.Elem.(type) {
+	case *schema.Schema:
+		result.Elem = userConfigOptionSchemaV0(elem)
+	case *schema.Resource:
+		nested := make(map[string]*schema.Schema, len(elem.Schema))
+		for k, v := range elem.Schema {
+			nested[k] = userConfigOptionSchemaV0(v)
+		}
+		result.Elem = &schema.Resource{Schema: nested}
+	}
+
+	return &result
+}
+
+// userConfigStateUpgradeV0 converts string values of integer, number and boolean user configuration
+// options to their actual types, values that were used to mark unset options are removed
+func userConfigStateUpgradeV0(configType string) schema.StateUpgradeFunc {
+	return func(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+		for key, value := range rawState {
+			if !strings.HasSuffix(key, "_user_config") {
+				continue
+			}
+
+			entrySchema, ok := templates.GetUserConfigSchema(configType)[strings.TrimSuffix(key, "_user_config")].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if list, ok := value.([]interface{}); ok {
+				for _, v := range list {
+					if userConfig, ok := v.(map[string]interface{}); ok {
+						upgradeUserConfigStateV0(userConfig, entrySchema)
+					}
+				}
+			}
+		}
+
+		return rawState, nil
+	}
+}
+
+func upgradeUserConfigStateV0(userConfig map[string]interface{}, definition map[string]interface{}) {
+	properties, ok := definition["properties"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for key, value := range userConfig {
+		propertyDefinition, ok := properties[decodeKeyName(key)].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		userConfig[key] = upgradeUserConfigValueStateV0(key, value, propertyDefinition)
+	}
+}
+
+func upgradeUserConfigValueStateV0(key string, value interface{}, definition map[string]interface{}) interface{} {
+	switch valueType := getAivenSchemaType(definition["type"]); valueType {
+	case "object":
+		// objects are lists with a single item, array items are stored without the list
+		switch v := value.(type) {
+		case []interface{}:
+			for _, item := range v {
+				if nested, ok := item.(map[string]interface{}); ok {
+					upgradeUserConfigStateV0(nested, definition)
+				}
+			}
+		case map[string]interface{}:
+			upgradeUserConfigStateV0(v, definition)
+		}
+	case "array":
+		itemDefinition, ok := definition["items"].(map[string]interface{})
+		if !ok {
+			return value
+		}
+		itemDefinition = selectFirstSchemaFromOneOf(itemDefinition)
+
+		if list, ok := value.([]interface{}); ok {
+			for i, v := range list {
+				list[i] = upgradeUserConfigValueStateV0(key, v, itemDefinition)
+			}
+		}
+	case "integer", "number", "boolean":
+		s, ok := value.(string)
+		if !ok {
+			return value
+		}
+
+		if canOmit(s, definition) {
+			return nil
+		}
+
+		v, err := convertUserConfigValueToSchemaType(valueType, s)
+		if err != nil {
+			// integers used to be accepted in a float format by the API
+			if f, errFloat := strconv.ParseFloat(s, 64); valueType == "integer" && errFloat == nil {
+				return int(f)
+			}
+
+			log.Printf("[WARN] cannot convert user config option %s value %q to %s, removing it from the state: %s",
+				key, s, valueType, err)
+			return nil
+		}
+		return v
+	}
+
+	return value
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_userConfigStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"service_name": "test-pg",
+		"pg_user_config": []interface{}{
+			map[string]interface{}{
+				"admin_username":            "admin",
+				"backup_hour":               "-1",
+				"backup_minute":             "",
+				"pg_read_replica":           "true",
+				"shared_buffers_percentage": "20.5",
+				"ip_filter":                 []interface{}{"0.0.0.0/0"},
+				"pg": []interface{}{
+					map[string]interface{}{
+						"max_wal_senders":                     "20",
+						"pg_partman_bgw__dot__interval":       "3600",
+						"jit":                                 "false",
+						"wal_sender_timeout":                  "0",
+						"idle_in_transaction_session_timeout": "<<value not set>>",
+					},
+				},
+			},
+		},
+	}
+
+	got, err := userConfigStateUpgradeV0("service")(context.Background(), rawState, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	userConfig := got["pg_user_config"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "admin", userConfig["admin_username"])
+	assert.Nil(t, userConfig["backup_hour"])
+	assert.Nil(t, userConfig["backup_minute"])
+	assert.Equal(t, true, userConfig["pg_read_replica"])
+	assert.Equal(t, 20.5, userConfig["shared_buffers_percentage"])
+	assert.Equal(t, []interface{}{"0.0.0.0/0"}, userConfig["ip_filter"])
+
+	pg := userConfig["pg"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, 20, pg["max_wal_senders"])
+	assert.Equal(t, 3600, pg["pg_partman_bgw__dot__interval"])
+	assert.Equal(t, false, pg["jit"])
+	assert.Equal(t, 0, pg["wal_sender_timeout"])
+	assert.Nil(t, pg["idle_in_transaction_session_timeout"])
+}
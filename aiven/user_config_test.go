@@ -7,11 +7,27 @@ import (
 	"testing"
 
 	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestGenerateTerraformUserConfigSchema(t *testing.T) {
+	adminPasswordDefinition := map[string]interface{}{
+		"createOnly": true,
+		"example":    "z66o9QXqKM",
+		"maxLength":  256,
+		"minLength":  8,
+		"testFloat":  9.9,
+		"pattern":    "^[a-zA-Z0-9-_]+$",
+		"title":      "Custom password for admin user",
+		"type": []interface{}{
+			"string",
+			"null",
+		},
+		"user_error": "Must consist of alpha-numeric characters, underscores or dashes",
+	}
+
 	type args struct {
 		data map[string]interface{}
 	}
@@ -26,20 +42,7 @@ func TestGenerateTerraformUserConfigSchema(t *testing.T) {
 			args{
 				data: map[string]interface{}{
 					"properties": map[string]interface{}{
-						"admin_password": map[string]interface{}{
-							"createOnly": true,
-							"example":    "z66o9QXqKM",
-							"maxLength":  256,
-							"minLength":  8,
-							"testFloat":  9.9,
-							"pattern":    "^[a-zA-Z0-9-_]+$",
-							"title":      "Custom password for admin user",
-							"type": []interface{}{
-								"string",
-								"null",
-							},
-							"user_error": "Must consist of alpha-numeric characters, underscores or dashes",
-						},
+						"admin_password": adminPasswordDefinition,
 					},
 				},
 			},
@@ -51,8 +54,9 @@ func TestGenerateTerraformUserConfigSchema(t *testing.T) {
 					Required:         false,
 					Computed:         false,
 					Sensitive:        true,
-					DiffSuppressFunc: createOnlyDiffSuppressFunc,
+					DiffSuppressFunc: userConfigCreateOnlyDiffSuppressFunc,
 					Description:      "Custom password for admin user",
+					ValidateFunc:     generateTerraformUserConfigValidateFunc(adminPasswordDefinition),
 				},
 			},
 			false,
@@ -169,6 +173,7 @@ func Test_convertTerraformUserConfigToAPICompatibleFormat(t *testing.T) {
 		newResource  bool
 		userConfig   map[string]interface{}
 		configSchema map[string]interface{}
+		rawConfig    cty.Value
 	}
 	tests := []struct {
 		name string
@@ -207,6 +212,7 @@ func Test_convertTerraformUserConfigToAPICompatibleFormat(t *testing.T) {
 					"schema_registry":      false,
 				},
 				configSchema: entrySchemaProps,
+				rawConfig:    cty.DynamicVal,
 			},
 			map[string]interface{}{
 				"ip_filter": []interface{}{
@@ -235,11 +241,117 @@ func Test_convertTerraformUserConfigToAPICompatibleFormat(t *testing.T) {
 				"schema_registry":      false,
 			},
 		},
+		{
+			"typed-options-not-set",
+			args{
+				serviceType: "kafka",
+				newResource: true,
+				userConfig: map[string]interface{}{
+					"kafka": []interface{}{
+						map[string]interface{}{
+							"auto_create_topics_enable": false,
+							"message_max_bytes":         0,
+							"log_retention_hours":       0,
+						},
+					},
+					"kafka_rest": true,
+				},
+				configSchema: entrySchemaProps,
+				rawConfig: cty.ObjectVal(map[string]cty.Value{
+					"kafka": cty.ListVal([]cty.Value{
+						cty.ObjectVal(map[string]cty.Value{
+							"auto_create_topics_enable": cty.NullVal(cty.Bool),
+							"message_max_bytes":         cty.NullVal(cty.Number),
+							"log_retention_hours":       cty.NumberIntVal(0),
+						}),
+					}),
+					"kafka_rest": cty.True,
+				}),
+			},
+			map[string]interface{}{
+				"kafka": map[string]interface{}{
+					"log_retention_hours": 0,
+				},
+				"kafka_rest": true,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := convertTerraformUserConfigToAPICompatibleFormat(tt.args.serviceType, tt.args.newResource, tt.args.userConfig, tt.args.configSchema)
+			got := convertTerraformUserConfigToAPICompatibleFormat(
+				tt.args.serviceType, tt.args.newResource, tt.args.userConfig, tt.args.configSchema, tt.args.rawConfig)
 			assert.Equal(t, got, tt.want)
 		})
 	}
 }
+
+func Test_convertAPIUserConfigToTerraformCompatibleFormat(t *testing.T) {
+	got := ConvertAPIUserConfigToTerraformCompatibleFormat("service", "kafka", map[string]interface{}{
+		"ip_filter": []interface{}{"10.0.0.0/8"},
+		"kafka": map[string]interface{}{
+			"auto_create_topics_enable":       true,
+			"log_cleaner_min_cleanable_ratio": 0.5,
+			"message_max_bytes":               float64(1000012),
+			"log_cleanup_policy":              "delete",
+		},
+		"kafka_rest": false,
+	})
+
+	if !assert.Len(t, got, 1) {
+		return
+	}
+	assert.Equal(t, []interface{}{"10.0.0.0/8"}, got[0]["ip_filter"])
+	assert.Equal(t, false, got[0]["kafka_rest"])
+	assert.Equal(t, false, got[0]["schema_registry"])
+
+	kafka := got[0]["kafka"].([]map[string]interface{})[0]
+	assert.Equal(t, true, kafka["auto_create_topics_enable"])
+	assert.Equal(t, 0.5, kafka["log_cleaner_min_cleanable_ratio"])
+	assert.Equal(t, 1000012, kafka["message_max_bytes"])
+	assert.Equal(t, "delete", kafka["log_cleanup_policy"])
+	assert.Equal(t, 0, kafka["log_retention_hours"])
+}
+
+func Test_userConfigOneOf(t *testing.T) {
+	definition := templates.GetUserConfigSchema("service")["m3db"].(map[string]interface{})
+
+	mapping := GenerateTerraformUserConfigSchema(definition)["rules"].Elem.(*schema.Resource).
+		Schema["mapping"].Elem.(*schema.Resource).Schema
+	if assert.Contains(t, mapping, "namespaces_object") {
+		assert.Equal(t, schema.TypeString, mapping["namespaces"].Elem.(*schema.Schema).Type)
+		assert.Contains(t, mapping["namespaces_object"].Elem.(*schema.Resource).Schema, "retention")
+	}
+
+	apiConfig := map[string]interface{}{
+		"rules": map[string]interface{}{
+			"mapping": []interface{}{
+				map[string]interface{}{
+					"filter":     "a:b",
+					"namespaces": []interface{}{"aggregated_*", map[string]interface{}{"resolution": "30s", "retention": "48h"}},
+				},
+			},
+		},
+	}
+
+	got := ConvertAPIUserConfigToTerraformCompatibleFormat("service", "m3db", apiConfig)
+	gotMapping := got[0]["rules"].([]map[string]interface{})[0]["mapping"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{"aggregated_*"}, gotMapping["namespaces"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"resolution": "30s", "retention": "48h"}}, gotMapping["namespaces_object"])
+
+	userConfig := map[string]interface{}{
+		"rules": []interface{}{
+			map[string]interface{}{
+				"mapping": []interface{}{
+					map[string]interface{}{
+						"filter":            "a:b",
+						"namespaces":        []interface{}{"aggregated_*"},
+						"namespaces_object": []interface{}{map[string]interface{}{"resolution": "30s", "retention": "48h"}},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, apiConfig, convertTerraformUserConfigToAPICompatibleFormat(
+		"m3db", true, userConfig, definition["properties"].(map[string]interface{}), cty.DynamicVal))
+}
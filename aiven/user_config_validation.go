@@ -0,0 +1,294 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// userConfigValueCheck validates a single user config value which is already converted
+// to its JSON schema type (string, int, float64 or bool)
+type userConfigValueCheck func(v interface{}, k string) error
+
+// generateTerraformUserConfigValidateFunc creates a ValidateFunc for a scalar user config
+// option based on the constraints of its JSON schema definition: type, enum, pattern,
+// minimum/maximum, minLength/maxLength and anyOf. When the definition carries a
+// `user_error` it is added to the error message. It returns nil if there is nothing
+// to validate.
+func generateTerraformUserConfigValidateFunc(definition map[string]interface{}) schema.SchemaValidateFunc {
+	valueType := getAivenSchemaType(definition["type"])
+	switch valueType {
+	case "string", "integer", "number", "boolean":
+	default:
+		return nil
+	}
+
+	checks := userConfigValueChecks(valueType, definition)
+	if valueType == "string" && len(checks) == 0 {
+		return nil
+	}
+
+	var userError string
+	if e, ok := definition["user_error"].(string); ok {
+		userError = strings.TrimSpace(e)
+	}
+
+	return func(i interface{}, k string) (ws []string, es []error) {
+		if canOmit(i, definition) {
+			return nil, nil
+		}
+
+		v, err := convertUserConfigValueToSchemaType(valueType, i)
+		if err != nil {
+			return nil, []error{fmt.Errorf("expected %q to be %s, got %v", k, userConfigTypeDescription(valueType), i)}
+		}
+
+		for _, check := range checks {
+			if err := check(v, k); err != nil {
+				if userError != "" {
+					err = fmt.Errorf("%s: %s", err, userError)
+				}
+				return nil, []error{err}
+			}
+		}
+
+		return nil, nil
+	}
+}
+
+// userConfigValueChecks collects all the checks that apply to a value of a given type
+func userConfigValueChecks(valueType string, definition map[string]interface{}) []userConfigValueCheck {
+	var checks []userConfigValueCheck
+
+	if enum, ok := definition["enum"].([]interface{}); ok && len(enum) > 0 {
+		checks = append(checks, userConfigEnumCheck(valueType, enum))
+	}
+
+	switch valueType {
+	case "string":
+		if pattern, ok := definition["pattern"].(string); ok {
+			if check := userConfigPatternCheck(pattern); check != nil {
+				checks = append(checks, check)
+			}
+		}
+		if minLength, ok := userConfigNumericKeyword(definition, "minLength"); ok {
+			checks = append(checks, func(v interface{}, k string) error {
+				if l := utf8.RuneCountInString(v.(string)); float64(l) < minLength {
+					return fmt.Errorf("expected length of %q to be at least %v, got %d", k, minLength, l)
+				}
+				return nil
+			})
+		}
+		if maxLength, ok := userConfigNumericKeyword(definition, "maxLength"); ok {
+			checks = append(checks, func(v interface{}, k string) error {
+				if l := utf8.RuneCountInString(v.(string)); float64(l) > maxLength {
+					return fmt.Errorf("expected length of %q to be at most %v, got %d", k, maxLength, l)
+				}
+				return nil
+			})
+		}
+	case "integer", "number":
+		if check := userConfigRangeCheck(definition); check != nil {
+			checks = append(checks, check)
+		}
+
+		// anyOf is only used to define several allowed ranges, the value must match at least one of them
+		if anyOf, ok := definition["anyOf"].([]interface{}); ok && len(anyOf) > 0 {
+			var alternatives []userConfigValueCheck
+			for _, a := range anyOf {
+				if alternative, ok := a.(map[string]interface{}); ok {
+					if check := userConfigRangeCheck(alternative); check != nil {
+						alternatives = append(alternatives, check)
+					}
+				}
+			}
+
+			if len(alternatives) > 0 {
+				checks = append(checks, func(v interface{}, k string) error {
+					var errs []string
+					for _, check := range alternatives {
+						err := check(v, k)
+						if err == nil {
+							return nil
+						}
+						errs = append(errs, err.Error())
+					}
+					return fmt.Errorf("%s", strings.Join(errs, " or "))
+				})
+			}
+		}
+	}
+
+	return checks
+}
+
+// userConfigRangeCheck creates a check for minimum and maximum keywords of a numeric value
+func userConfigRangeCheck(definition map[string]interface{}) userConfigValueCheck {
+	minimum, hasMinimum := userConfigNumericKeyword(definition, "minimum")
+	maximum, hasMaximum := userConfigNumericKeyword(definition, "maximum")
+	if !hasMinimum && !hasMaximum {
+		return nil
+	}
+
+	return func(v interface{}, k string) error {
+		n := userConfigValueAsFloat64(v)
+		switch {
+		case hasMinimum && hasMaximum && (n < minimum || n > maximum):
+			return fmt.Errorf("expected %q to be in the range (%v - %v), got %v", k, minimum, maximum, v)
+		case hasMinimum && n < minimum:
+			return fmt.Errorf("expected %q to be at least (%v), got %v", k, minimum, v)
+		case hasMaximum && n > maximum:
+			return fmt.Errorf("expected %q to be at most (%v), got %v", k, maximum, v)
+		}
+		return nil
+	}
+}
+
+// userConfigEnumCheck creates a check which ensures that a value is one of the enum values,
+// null values of the enum are ignored since those cannot be set from Terraform
+func userConfigEnumCheck(valueType string, enum []interface{}) userConfigValueCheck {
+	var allowed []interface{}
+	for _, e := range enum {
+		if e != nil {
+			allowed = append(allowed, e)
+		}
+	}
+
+	return func(v interface{}, k string) error {
+		for _, e := range allowed {
+			switch valueType {
+			case "integer", "number":
+				if n, ok := e.(float64); ok && n == userConfigValueAsFloat64(v) {
+					return nil
+				}
+			default:
+				if fmt.Sprint(e) == fmt.Sprint(v) {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("expected %q to be one of %q, got %v", k, flattenToString(allowed), v)
+	}
+}
+
+// userConfigPatternCheck creates a check for the pattern keyword. Aiven user config
+// schemas use ECMA 262 regular expressions and some of them start with a negative
+// lookahead `^(?!...)`, which is not supported by Go, such patterns are split into a
+// regular expression that must not match and one that must match.
+func userConfigPatternCheck(pattern string) userConfigValueCheck {
+	var mustNotMatch *regexp.Regexp
+	mustMatchPattern := pattern
+
+	if strings.HasPrefix(pattern, "^(?!") {
+		end := userConfigPatternGroupEnd(pattern, len("^"))
+		if end < 0 {
+			log.Printf("[WARN] unsupported user config pattern %q, skipping validation", pattern)
+			return nil
+		}
+
+		var err error
+		mustNotMatch, err = regexp.Compile("^(?:" + pattern[len("^(?!"):end] + ")")
+		if err != nil {
+			log.Printf("[WARN] unsupported user config pattern %q, skipping validation: %s", pattern, err)
+			return nil
+		}
+		mustMatchPattern = "^" + pattern[end+1:]
+	}
+
+	mustMatch, err := regexp.Compile(mustMatchPattern)
+	if err != nil {
+		log.Printf("[WARN] unsupported user config pattern %q, skipping validation: %s", pattern, err)
+		return nil
+	}
+
+	return func(v interface{}, k string) error {
+		s := v.(string)
+		if !mustMatch.MatchString(s) || (mustNotMatch != nil && mustNotMatch.MatchString(s)) {
+			return fmt.Errorf("expected %q to match regular expression %q, got %v", k, pattern, s)
+		}
+		return nil
+	}
+}
+
+// userConfigPatternGroupEnd returns the index of the parenthesis closing the group
+// opened at the given index or -1 if the group is not closed
+func userConfigPatternGroupEnd(pattern string, start int) int {
+	depth := 0
+	inClass := false
+	for i := start; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\':
+			i++
+		case inClass:
+			inClass = c != ']'
+		case c == '[':
+			inClass = true
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// convertUserConfigValueToSchemaType converts a Terraform value into the JSON schema type
+func convertUserConfigValueToSchemaType(valueType string, value interface{}) (interface{}, error) {
+	switch valueType {
+	case "integer":
+		return convertTerraformUserConfigValueToAPICompatibleFormatInteger(value)
+	case "number":
+		return convertTerraformUserConfigValueToAPICompatibleFormatNumber(value)
+	case "boolean":
+		return convertTerraformUserConfigValueToAPICompatibleFormatBoolean(value)
+	default:
+		return convertTerraformUserConfigValueToAPICompatibleFormatString(value)
+	}
+}
+
+func userConfigTypeDescription(valueType string) string {
+	switch valueType {
+	case "integer":
+		return "an integer"
+	case "number":
+		return "a number"
+	case "boolean":
+		return "a boolean"
+	default:
+		return "a string"
+	}
+}
+
+// userConfigNumericKeyword reads a numeric JSON schema keyword, JSON decoding produces
+// float64 values while definitions created in the code may use integers
+func userConfigNumericKeyword(definition map[string]interface{}, keyword string) (float64, bool) {
+	switch v := definition[keyword].(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+func userConfigValueAsFloat64(v interface{}) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case float64:
+		return n
+	default:
+		return math.NaN()
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/stretchr/testify/assert"
+)
+
+// userConfigTestDefinition returns the JSON schema definition found by following
+// the given path of properties (or items, for arrays)
+func userConfigTestDefinition(configType, entryType string, path ...string) map[string]interface{} {
+	definition := templates.GetUserConfigSchema(configType)[entryType].(map[string]interface{})
+	for _, p := range path {
+		if p == "[]" {
+			definition = definition["items"].(map[string]interface{})
+			continue
+		}
+		definition = definition["properties"].(map[string]interface{})[p].(map[string]interface{})
+	}
+	return definition
+}
+
+func Test_generateTerraformUserConfigValidateFunc(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition map[string]interface{}
+		value      interface{}
+		wantErr    string
+	}{
+		{
+			"enum-valid",
+			userConfigTestDefinition("service", "kafka", "kafka", "log_cleanup_policy"),
+			"compact,delete",
+			"",
+		},
+		{
+			"enum-invalid",
+			userConfigTestDefinition("service", "kafka", "kafka", "log_cleanup_policy"),
+			"compcat",
+			"to be one of",
+		},
+		{
+			"enum-integer",
+			userConfigTestDefinition("service", "kafka", "kafka_rest_config", "consumer_request_timeout_ms"),
+			"15000",
+			"",
+		},
+		{
+			"not-set",
+			userConfigTestDefinition("service", "kafka", "kafka", "log_cleanup_policy"),
+			"",
+			"",
+		},
+		{
+			"integer-not-a-number",
+			userConfigTestDefinition("service", "kafka", "kafka", "message_max_bytes"),
+			"1MB",
+			"to be an integer",
+		},
+		{
+			"integer-out-of-range",
+			userConfigTestDefinition("service", "kafka", "kafka", "message_max_bytes"),
+			"100001201",
+			"to be in the range",
+		},
+		{
+			"boolean-invalid",
+			userConfigTestDefinition("service", "kafka", "kafka", "auto_create_topics_enable"),
+			"yes",
+			"to be a boolean",
+		},
+		{
+			"any-of-valid-zero",
+			userConfigTestDefinition("service", "pg", "pg", "wal_sender_timeout"),
+			"0",
+			"",
+		},
+		{
+			"any-of-invalid",
+			userConfigTestDefinition("service", "pg", "pg", "wal_sender_timeout"),
+			"100",
+			"Must be either 0 or between 5000 and 10800000.",
+		},
+		{
+			"negative-lookahead-valid",
+			userConfigTestDefinition("integration", "datadog", "datadog_tags", "[]", "tag"),
+			"env:prod",
+			"",
+		},
+		{
+			"negative-lookahead-reserved-prefix",
+			userConfigTestDefinition("integration", "datadog", "datadog_tags", "[]", "tag"),
+			"aiven-env",
+			"Tags with prefix 'aiven-' are reserved for Aiven.",
+		},
+		{
+			"pattern-ending-with-colon",
+			userConfigTestDefinition("integration", "datadog", "datadog_tags", "[]", "tag"),
+			"env:",
+			"to match regular expression",
+		},
+		{
+			"max-length",
+			userConfigTestDefinition("service", "pg", "ip_filter", "[]"),
+			"10.20.0.0/16 10.30.0.0/16",
+			"to be at most 18",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := generateTerraformUserConfigValidateFunc(tt.definition)
+			if !assert.NotNil(t, f) {
+				return
+			}
+
+			_, errs := f(tt.value, "key")
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+
+			if assert.Len(t, errs, 1) {
+				assert.Contains(t, errs[0].Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_userConfigPatternCheckSupportsAllEmbeddedPatterns(t *testing.T) {
+	var walk func(path string, definition map[string]interface{})
+	walk = func(path string, definition map[string]interface{}) {
+		if pattern, ok := definition["pattern"].(string); ok {
+			assert.NotNil(t, userConfigPatternCheck(pattern), "pattern %q of %s is not supported", pattern, path)
+		}
+		if properties, ok := definition["properties"].(map[string]interface{}); ok {
+			for k, v := range properties {
+				walk(path+"."+k, v.(map[string]interface{}))
+			}
+		}
+		if items, ok := definition["items"].(map[string]interface{}); ok {
+			walk(path+".[]", items)
+		}
+		if oneOf, ok := definition["oneOf"].([]interface{}); ok {
+			for _, v := range oneOf {
+				walk(path, v.(map[string]interface{}))
+			}
+		}
+	}
+
+	for _, configType := range []string{
+		templates.UserConfigSchemaService,
+		templates.UserConfigSchemaIntegration,
+		templates.UserConfigSchemaEndpoint,
+	} {
+		for entryType, definition := range templates.GetUserConfigSchema(configType) {
+			walk(strings.Join([]string{configType, entryType}, "."), definition.(map[string]interface{}))
+		}
+	}
+}
@@ -0,0 +1,12 @@
+data "aiven_cloud" "helsinki" {
+  project   = data.aiven_project.foo.project
+  latitude  = 60.17
+  longitude = 24.94
+}
+
+resource "aiven_pg" "pg" {
+  project      = data.aiven_project.foo.project
+  cloud_name   = data.aiven_cloud.helsinki.cloud_name
+  plan         = "startup-4"
+  service_name = "my-pg"
+}
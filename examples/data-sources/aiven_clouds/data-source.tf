@@ -0,0 +1,4 @@
+data "aiven_clouds" "google" {
+  project        = data.aiven_project.foo.project
+  cloud_provider = "google"
+}
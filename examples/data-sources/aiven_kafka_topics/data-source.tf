@@ -0,0 +1,20 @@
+data "aiven_kafka_topics" "orders" {
+  project        = aiven_kafka.kafka.project
+  service_name   = aiven_kafka.kafka.service_name
+  name_regex     = "^orders-"
+  cleanup_policy = "compact"
+
+  tag {
+    key   = "owner"
+    value = "orders-team"
+  }
+}
+
+data "aiven_kafka_topics" "all" {
+  project      = aiven_kafka.kafka.project
+  service_name = aiven_kafka.kafka.service_name
+}
+
+output "untagged_topics" {
+  value = [for t in data.aiven_kafka_topics.all.topics : t.topic_name if length(t.tag) == 0]
+}
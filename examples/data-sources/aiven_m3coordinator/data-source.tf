@@ -0,0 +1,4 @@
+data "aiven_m3coordinator" "m3c" {
+  project      = data.aiven_project.foo.project
+  service_name = "my-m3c"
+}
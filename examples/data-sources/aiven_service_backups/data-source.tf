@@ -0,0 +1,12 @@
+data "aiven_service_backups" "pg" {
+  project      = aiven_pg.pg.project
+  service_name = aiven_pg.pg.service_name
+}
+
+output "pg_latest_backup_time" {
+  value = data.aiven_service_backups.pg.latest_backup_time
+}
+
+output "pg_pitr_earliest_time" {
+  value = data.aiven_service_backups.pg.pitr_earliest_time
+}
@@ -0,0 +1,14 @@
+data "aiven_service_plan" "pg" {
+  project            = data.aiven_project.foo.project
+  service_type       = "pg"
+  cloud_name         = "google-europe-west1"
+  min_node_count     = 2
+  min_node_memory_mb = 8192
+}
+
+resource "aiven_pg" "pg" {
+  project      = data.aiven_project.foo.project
+  cloud_name   = "google-europe-west1"
+  plan         = data.aiven_service_plan.pg.service_plan
+  service_name = "my-pg"
+}
@@ -0,0 +1,7 @@
+data "aiven_service_plans" "kafka" {
+  project                    = data.aiven_project.foo.project
+  service_type               = "kafka"
+  cloud_name                 = "google-europe-west1"
+  min_node_cpu_count         = 4
+  max_base_price_monthly_usd = 2000
+}
@@ -5,7 +5,7 @@ resource "aiven_m3db" "m3db" {
   service_name = "m3db"
 
   m3db_user_config {
-    m3db_version = 1.0
+    m3db_version = 1.1
 
     namespaces {
       name = "test-acc-%s"
@@ -65,7 +65,7 @@ resource "aiven_m3aggregator" "m3a" {
   service_name = "m3a"
 
   m3aggregator_user_config {
-    m3aggregator_version = 1.0
+    m3aggregator_version = 1.1
   }
 }
 
@@ -74,4 +74,22 @@ resource "aiven_service_integration" "int-m3db-aggr" {
   integration_type         = "m3aggregator"
   source_service_name      = aiven_m3db.m3db.service_name
   destination_service_name = aiven_m3aggregator.m3a.service_name
-}
\ No newline at end of file
+}
+// Setting up a coordinator
+resource "aiven_m3coordinator" "m3c" {
+  project      = data.aiven_project.m3db-project1.project
+  cloud_name   = "google-europe-west1"
+  plan         = "startup-8"
+  service_name = "m3c"
+
+  m3coordinator_user_config {
+    m3coordinator_version = 1.1
+  }
+}
+
+resource "aiven_service_integration" "int-m3db-coord" {
+  project                  = data.aiven_project.m3db-project1.project
+  integration_type         = "m3coordinator"
+  source_service_name      = aiven_m3db.m3db.service_name
+  destination_service_name = aiven_m3coordinator.m3c.service_name
+}
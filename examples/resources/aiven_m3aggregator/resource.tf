@@ -7,6 +7,6 @@ resource "aiven_m3aggregator" "m3a" {
   maintenance_window_time = "10:00:00"
 
   m3aggregator_user_config {
-    m3aggregator_version = 0.15
+    m3aggregator_version = 1.1
   }
 }
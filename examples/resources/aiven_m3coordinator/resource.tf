@@ -0,0 +1,19 @@
+resource "aiven_m3coordinator" "m3c" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-8"
+  service_name            = "my-m3c"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+
+  m3coordinator_user_config {
+    m3coordinator_version = 1.1
+  }
+}
+
+resource "aiven_service_integration" "m3db-m3c" {
+  project                  = data.aiven_project.foo.project
+  integration_type         = "m3coordinator"
+  source_service_name      = aiven_m3db.m3db.service_name
+  destination_service_name = aiven_m3coordinator.m3c.service_name
+}
@@ -0,0 +1,5 @@
+resource "aiven_service_maintenance" "pg" {
+  project      = aiven_pg.pg.project
+  service_name = aiven_pg.pg.service_name
+  trigger      = "2022-01-17"
+}
@@ -0,0 +1,19 @@
+resource "aiven_static_ip" "ips" {
+  count        = 3
+  project      = data.aiven_project.foo.project
+  cloud_name   = "google-europe-west1"
+  service_name = "my-pg"
+}
+
+resource "aiven_pg" "pg" {
+  project      = data.aiven_project.foo.project
+  cloud_name   = "google-europe-west1"
+  plan         = "business-4"
+  service_name = "my-pg"
+
+  pg_user_config {
+    static_ips = true
+  }
+
+  depends_on = [aiven_static_ip.ips]
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+
+package aivenapi
+
+import (
+	"github.com/aiven/aiven-go-client"
+)
+
+// ServiceBackup is a backup of a service, the backups returned with the service do not have names
+type ServiceBackup struct {
+	BackupName      string `json:"backup_name"`
+	BackupTime      string `json:"backup_time"`
+	DataSize        int    `json:"data_size"`
+	StorageLocation string `json:"storage_location"`
+}
+
+// ListServiceBackups returns the backups of a service
+func ListServiceBackups(client *aiven.Client, project, service string) ([]ServiceBackup, error) {
+	var r struct {
+		Backups []ServiceBackup `json:"backups"`
+	}
+	if err := doGetRequest(client, buildPath("project", project, "service", service, "backups"), &r); err != nil {
+		return nil, err
+	}
+
+	return r.Backups, nil
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aivenapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListServiceBackups(t *testing.T) {
+	client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/project/project/service/pg-prod/backups", r.URL.Path)
+		_, _ = w.Write([]byte(`{"backups": [{"backup_name": "2022-01-10_10-00_0.00000000.pghoard", ` +
+			`"backup_time": "2022-01-10T10:00:00.000000Z", "data_size": 1024, "storage_location": "gs://bucket"}]}`))
+	})
+
+	got, err := ListServiceBackups(client, "project", "pg-prod")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []ServiceBackup{{
+			BackupName:      "2022-01-10_10-00_0.00000000.pghoard",
+			BackupTime:      "2022-01-10T10:00:00.000000Z",
+			DataSize:        1024,
+			StorageLocation: "gs://bucket",
+		}}, got)
+	}
+}
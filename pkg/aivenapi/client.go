@@ -0,0 +1,94 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+
+// Package aivenapi implements the Aiven API calls that are not available in the version of
+// aiven-go-client used by the provider. The requests are made with the credentials and the
+// HTTP client of an aiven.Client, errors are returned as aiven.Error so that the helpers of
+// aiven-go-client, such as aiven.IsNotFound, work with them.
+package aivenapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+// apiURL is the base URL of the Aiven API, it can be changed with the AIVEN_WEB_URL
+// environment variable the same way as in aiven-go-client
+var apiURL = "https://api.aiven.io/v1"
+
+func init() {
+	if value, isSet := os.LookupEnv("AIVEN_WEB_URL"); isSet {
+		apiURL = value + "/v1"
+	}
+}
+
+// buildPath joins the path elements, escaping each of them
+func buildPath(parts ...string) string {
+	var b strings.Builder
+	for _, part := range parts {
+		b.WriteString("/")
+		b.WriteString(url.PathEscape(part))
+	}
+	return b.String()
+}
+
+func doGetRequest(client *aiven.Client, path string, result interface{}) error {
+	return doRequest(client, http.MethodGet, path, nil, result)
+}
+
+func doRequest(client *aiven.Client, method, path string, body interface{}, result interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, apiURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", client.UserAgent)
+	req.Header.Set("Authorization", "aivenv1 "+client.APIKey)
+
+	httpClient := client.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	rsp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rsp.Body.Close() }()
+
+	b, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return err
+	}
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return aiven.Error{Message: string(b), Status: rsp.StatusCode}
+	}
+
+	if result == nil || len(b) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(b, result); err != nil {
+		return fmt.Errorf("cannot decode the response of %s %s: %w", method, path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+
+package aivenapi
+
+import (
+	"github.com/aiven/aiven-go-client"
+)
+
+// Cloud is a cloud region services can be created in
+type Cloud struct {
+	CloudName           string  `json:"cloud_name"`
+	CloudDescription    string  `json:"cloud_description"`
+	GeoLatitude         float64 `json:"geo_latitude"`
+	GeoLongitude        float64 `json:"geo_longitude"`
+	GeoRegion           string  `json:"geo_region"`
+	Provider            string  `json:"provider"`
+	ProviderDescription string  `json:"provider_description"`
+}
+
+// ListClouds returns the clouds available to a project
+func ListClouds(client *aiven.Client, project string) ([]Cloud, error) {
+	var r struct {
+		Clouds []Cloud `json:"clouds"`
+	}
+	if err := doGetRequest(client, buildPath("project", project, "clouds"), &r); err != nil {
+		return nil, err
+	}
+
+	return r.Clouds, nil
+}
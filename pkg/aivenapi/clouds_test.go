@@ -0,0 +1,31 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aivenapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListClouds(t *testing.T) {
+	client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/project/project/clouds", r.URL.Path)
+		_, _ = w.Write([]byte(`{"clouds": [{"cloud_description": "Europe, Belgium - Google Cloud: Belgium", ` +
+			`"cloud_name": "google-europe-west1", "geo_latitude": 50.4, "geo_longitude": 3.8, "geo_region": "europe", ` +
+			`"provider": "google", "provider_description": "Google Cloud Platform"}]}`))
+	})
+
+	got, err := ListClouds(client, "project")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Cloud{{
+			CloudName:           "google-europe-west1",
+			CloudDescription:    "Europe, Belgium - Google Cloud: Belgium",
+			GeoLatitude:         50.4,
+			GeoLongitude:        3.8,
+			GeoRegion:           "europe",
+			Provider:            "google",
+			ProviderDescription: "Google Cloud Platform",
+		}}, got)
+	}
+}
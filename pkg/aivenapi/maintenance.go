@@ -0,0 +1,72 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+
+package aivenapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+// ServiceMaintenance is the maintenance window of a service and the maintenance updates that are
+// pending, the updates are installed during the maintenance window or when maintenance is started
+type ServiceMaintenance struct {
+	DayOfWeek string                     `json:"dow"`
+	TimeOfDay string                     `json:"time"`
+	Updates   []ServiceMaintenanceUpdate `json:"updates"`
+}
+
+// ServiceMaintenanceUpdate is a pending maintenance update of a service, it is installed before the
+// deadline at the latest and not before the start after time
+type ServiceMaintenanceUpdate struct {
+	Deadline    string `json:"deadline"`
+	Description string `json:"description"`
+	StartAfter  string `json:"start_after"`
+	StartAt     string `json:"start_at"`
+}
+
+// GetServiceMaintenance returns the maintenance window and the pending maintenance updates of a
+// service, the service response of aiven-go-client does not include the updates
+func GetServiceMaintenance(client *aiven.Client, project, service string) (*ServiceMaintenance, error) {
+	var r struct {
+		Service struct {
+			Maintenance ServiceMaintenance `json:"maintenance"`
+		} `json:"service"`
+	}
+	if err := doGetRequest(client, buildPath("project", project, "service", service), &r); err != nil {
+		return nil, err
+	}
+
+	return &r.Service.Maintenance, nil
+}
+
+// GetServiceWithMaintenance returns a service and its pending maintenance updates with one request,
+// the service is decoded the same way as by aiven-go-client
+func GetServiceWithMaintenance(client *aiven.Client, project, service string) (*aiven.Service, *ServiceMaintenance, error) {
+	var r struct {
+		Service json.RawMessage `json:"service"`
+	}
+	if err := doGetRequest(client, buildPath("project", project, "service", service), &r); err != nil {
+		return nil, nil, err
+	}
+
+	var s aiven.Service
+	if err := json.Unmarshal(r.Service, &s); err != nil {
+		return nil, nil, fmt.Errorf("cannot decode service %s: %w", service, err)
+	}
+	var m struct {
+		Maintenance ServiceMaintenance `json:"maintenance"`
+	}
+	if err := json.Unmarshal(r.Service, &m); err != nil {
+		return nil, nil, fmt.Errorf("cannot decode the maintenance of service %s: %w", service, err)
+	}
+
+	return &s, &m.Maintenance, nil
+}
+
+// StartServiceMaintenance starts the maintenance of a service, which installs the pending updates
+func StartServiceMaintenance(client *aiven.Client, project, service string) error {
+	return doRequest(client, http.MethodPut, buildPath("project", project, "service", service, "maintenance", "start"), nil, nil)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aivenapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetServiceMaintenance(t *testing.T) {
+	client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/project/project/service/pg-prod", r.URL.Path)
+		_, _ = w.Write([]byte(`{"service": {"service_name": "pg-prod", "maintenance": {"dow": "monday", "time": "10:00:00", ` +
+			`"updates": [{"deadline": "2022-02-01T10:00:00Z", "description": "Update to the latest PostgreSQL minor version", ` +
+			`"start_after": "2022-01-17T10:00:00Z", "start_at": null}]}}}`))
+	})
+
+	got, err := GetServiceMaintenance(client, "project", "pg-prod")
+	if assert.NoError(t, err) {
+		assert.Equal(t, &ServiceMaintenance{
+			DayOfWeek: "monday",
+			TimeOfDay: "10:00:00",
+			Updates: []ServiceMaintenanceUpdate{{
+				Deadline:    "2022-02-01T10:00:00Z",
+				Description: "Update to the latest PostgreSQL minor version",
+				StartAfter:  "2022-01-17T10:00:00Z",
+			}},
+		}, got)
+	}
+}
+
+func TestGetServiceWithMaintenance(t *testing.T) {
+	client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/project/project/service/pg-prod", r.URL.Path)
+		_, _ = w.Write([]byte(`{"service": {"service_name": "pg-prod", "service_type": "pg", "plan": "startup-4", ` +
+			`"maintenance": {"dow": "monday", "time": "10:00:00", "updates": [{"deadline": "2022-02-01T10:00:00Z", ` +
+			`"description": "Update to the latest PostgreSQL minor version", "start_after": "2022-01-17T10:00:00Z", "start_at": null}]}}}`))
+	})
+
+	s, maintenance, err := GetServiceWithMaintenance(client, "project", "pg-prod")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "pg-prod", s.Name)
+		assert.Equal(t, "pg", s.Type)
+		assert.Equal(t, "startup-4", s.Plan)
+		assert.Equal(t, aiven.MaintenanceWindow{DayOfWeek: "monday", TimeOfDay: "10:00:00"}, s.MaintenanceWindow)
+		assert.Equal(t, []ServiceMaintenanceUpdate{{
+			Deadline:    "2022-02-01T10:00:00Z",
+			Description: "Update to the latest PostgreSQL minor version",
+			StartAfter:  "2022-01-17T10:00:00Z",
+		}}, maintenance.Updates)
+	}
+}
+
+func TestStartServiceMaintenance(t *testing.T) {
+	client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/v1/project/project/service/pg-prod/maintenance/start", r.URL.Path)
+		_, _ = w.Write([]byte(`{"message": "Maintenance started"}`))
+	})
+
+	assert.NoError(t, StartServiceMaintenance(client, "project", "pg-prod"))
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+
+package aivenapi
+
+import (
+	"net/http"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+// ServicePlan is a plan of a service type
+type ServicePlan struct {
+	ServicePlan     string                       `json:"service_plan"`
+	ServiceType     string                       `json:"service_type"`
+	NodeCount       int                          `json:"node_count"`
+	ShardCount      int                          `json:"shard_count"`
+	DiskSpaceMB     int                          `json:"disk_space_mb"`
+	DiskSpaceCapMB  int                          `json:"disk_space_cap_mb"`
+	DiskSpaceStepMB int                          `json:"disk_space_step_mb"`
+	BackupConfig    ServicePlanBackupConfig      `json:"backup_config"`
+	Regions         map[string]ServicePlanRegion `json:"regions"`
+}
+
+// ServicePlanBackupConfig is the backup configuration of a service plan
+type ServicePlanBackupConfig struct {
+	Interval     int    `json:"interval"`
+	MaxCount     int    `json:"max_count"`
+	RecoveryMode string `json:"recovery_mode"`
+}
+
+// ServicePlanRegion is the node size and price of a service plan in a cloud, the disk space
+// overrides the one of the plan when set
+type ServicePlanRegion struct {
+	DiskSpaceMB     int    `json:"disk_space_mb"`
+	DiskSpaceCapMB  int    `json:"disk_space_cap_mb"`
+	DiskSpaceStepMB int    `json:"disk_space_step_mb"`
+	NodeCPUCount    int    `json:"node_cpu_count"`
+	NodeMemoryMB    int    `json:"node_memory_mb"`
+	PriceUSD        string `json:"price_usd"`
+}
+
+// GetServicePlan returns a plan of a service type available in a project
+func GetServicePlan(client *aiven.Client, project, serviceType, servicePlan string) (*ServicePlan, error) {
+	var r ServicePlan
+	if err := doGetRequest(client, buildPath("project", project, "service-types", serviceType, "plans", servicePlan), &r); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// ListServicePlans returns the plans of a service type available in a project, an aiven.Error
+// with the status 404 is returned when the service type is not available
+func ListServicePlans(client *aiven.Client, project, serviceType string) ([]ServicePlan, error) {
+	var r struct {
+		ServiceTypes map[string]struct {
+			ServicePlans []ServicePlan `json:"service_plans"`
+		} `json:"service_types"`
+	}
+	if err := doGetRequest(client, buildPath("project", project, "service-types"), &r); err != nil {
+		return nil, err
+	}
+
+	t, ok := r.ServiceTypes[serviceType]
+	if !ok {
+		return nil, aiven.Error{Message: "Service type not found", Status: http.StatusNotFound}
+	}
+
+	return t.ServicePlans, nil
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aivenapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListServicePlans(t *testing.T) {
+	client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/project/project/service-types", r.URL.Path)
+		_, _ = w.Write([]byte(`{"service_types": {"pg": {"service_plans": [{"service_plan": "startup-4", ` +
+			`"service_type": "pg", "node_count": 1, "disk_space_mb": 81920, "backup_config": {"interval": 24, ` +
+			`"max_count": 2, "recovery_mode": "pitr"}, "regions": {"google-europe-west1": {"node_cpu_count": 1, ` +
+			`"node_memory_mb": 4096, "price_usd": "0.0260"}}}]}}}`))
+	})
+
+	got, err := ListServicePlans(client, "project", "pg")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []ServicePlan{{
+			ServicePlan:  "startup-4",
+			ServiceType:  "pg",
+			NodeCount:    1,
+			DiskSpaceMB:  81920,
+			BackupConfig: ServicePlanBackupConfig{Interval: 24, MaxCount: 2, RecoveryMode: "pitr"},
+			Regions: map[string]ServicePlanRegion{
+				"google-europe-west1": {NodeCPUCount: 1, NodeMemoryMB: 4096, PriceUSD: "0.0260"},
+			},
+		}}, got)
+	}
+
+	_, err = ListServicePlans(client, "project", "kafka")
+	assert.True(t, aiven.IsNotFound(err))
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+
+package aivenapi
+
+import (
+	"net/http"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+// StaticIP is a static IP address of a project. A new address is `creating` until it is `created`, it
+// is `available` when it is associated with a service and `assigned` when a node of the service uses it.
+type StaticIP struct {
+	CloudName         string `json:"cloud_name"`
+	IPAddress         string `json:"ip_address"`
+	ServiceName       string `json:"service_name"`
+	State             string `json:"state"`
+	StaticIPAddressID string `json:"static_ip_address_id"`
+}
+
+// CreateStaticIP allocates a static IP address in a cloud of a project
+func CreateStaticIP(client *aiven.Client, project, cloudName string) (*StaticIP, error) {
+	var r StaticIP
+	req := struct {
+		CloudName string `json:"cloud_name"`
+	}{cloudName}
+	if err := doRequest(client, http.MethodPost, buildPath("project", project, "static-ips"), req, &r); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// ListStaticIPs returns the static IP addresses of a project
+func ListStaticIPs(client *aiven.Client, project string) ([]StaticIP, error) {
+	var r struct {
+		StaticIPs []StaticIP `json:"static_ips"`
+	}
+	if err := doGetRequest(client, buildPath("project", project, "static-ips"), &r); err != nil {
+		return nil, err
+	}
+
+	return r.StaticIPs, nil
+}
+
+// GetStaticIP returns a static IP address of a project, an aiven.Error with the status 404 is
+// returned when the address does not exist
+func GetStaticIP(client *aiven.Client, project, staticIPAddressID string) (*StaticIP, error) {
+	staticIPs, err := ListStaticIPs(client, project)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range staticIPs {
+		if staticIPs[i].StaticIPAddressID == staticIPAddressID {
+			return &staticIPs[i], nil
+		}
+	}
+
+	return nil, aiven.Error{Message: "Static IP address not found", Status: http.StatusNotFound}
+}
+
+// DeleteStaticIP releases a static IP address of a project
+func DeleteStaticIP(client *aiven.Client, project, staticIPAddressID string) error {
+	return doRequest(client, http.MethodDelete, buildPath("project", project, "static-ips", staticIPAddressID), nil, nil)
+}
+
+// AssociateStaticIP associates a static IP address with a service in the same cloud
+func AssociateStaticIP(client *aiven.Client, project, staticIPAddressID, serviceName string) error {
+	req := struct {
+		ServiceName string `json:"service_name"`
+	}{serviceName}
+	return doRequest(client, http.MethodPost,
+		buildPath("project", project, "static-ips", staticIPAddressID, "association"), req, nil)
+}
+
+// DissociateStaticIP dissociates a static IP address from its service
+func DissociateStaticIP(client *aiven.Client, project, staticIPAddressID string) error {
+	return doRequest(client, http.MethodDelete,
+		buildPath("project", project, "static-ips", staticIPAddressID, "association"), nil, nil)
+}
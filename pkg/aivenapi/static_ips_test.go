@@ -0,0 +1,55 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aivenapi
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateStaticIP(t *testing.T) {
+	client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/project/project/static-ips", r.URL.Path)
+		b, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"cloud_name": "google-europe-west1"}`, string(b))
+		_, _ = w.Write([]byte(`{"cloud_name": "google-europe-west1", "ip_address": "", "service_name": null, ` +
+			`"state": "creating", "static_ip_address_id": "ip358375b2765"}`))
+	})
+
+	got, err := CreateStaticIP(client, "project", "google-europe-west1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, &StaticIP{CloudName: "google-europe-west1", State: "creating", StaticIPAddressID: "ip358375b2765"}, got)
+	}
+}
+
+func TestGetStaticIP(t *testing.T) {
+	client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/project/project/static-ips", r.URL.Path)
+		_, _ = w.Write([]byte(`{"static_ips": [{"ip_address": "10.0.0.1", "service_name": "pg", ` +
+			`"state": "assigned", "static_ip_address_id": "ip358375b2765"}]}`))
+	})
+
+	got, err := GetStaticIP(client, "project", "ip358375b2765")
+	if assert.NoError(t, err) {
+		assert.Equal(t, &StaticIP{IPAddress: "10.0.0.1", ServiceName: "pg", State: "assigned", StaticIPAddressID: "ip358375b2765"}, got)
+	}
+
+	_, err = GetStaticIP(client, "project", "unknown")
+	assert.True(t, aiven.IsNotFound(err))
+}
+
+func TestAssociateStaticIP(t *testing.T) {
+	client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/project/project/static-ips/ip358375b2765/association", r.URL.Path)
+		b, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"service_name": "pg"}`, string(b))
+		_, _ = w.Write([]byte(`{"message": "Static IP address associated"}`))
+	})
+
+	assert.NoError(t, AssociateStaticIP(client, "project", "ip358375b2765", "pg"))
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+
+package aivenapi
+
+import (
+	"github.com/aiven/aiven-go-client"
+)
+
+// ServiceType is a service type supported by Aiven
+type ServiceType struct {
+	Description      string                 `json:"description"`
+	UserConfigSchema map[string]interface{} `json:"user_config_schema"`
+}
+
+// IntegrationType is a service integration type supported by Aiven
+type IntegrationType struct {
+	IntegrationType    string                 `json:"integration_type"`
+	Description        string                 `json:"description"`
+	SourceServiceTypes []string               `json:"source_service_types"`
+	DestServiceTypes   []string               `json:"dest_service_types"`
+	UserConfigSchema   map[string]interface{} `json:"user_config_schema"`
+}
+
+// IntegrationEndpointType is a service integration endpoint type supported by Aiven
+type IntegrationEndpointType struct {
+	EndpointType     string                 `json:"endpoint_type"`
+	Title            string                 `json:"title"`
+	ServiceTypes     []string               `json:"service_types"`
+	UserConfigSchema map[string]interface{} `json:"user_config_schema"`
+}
+
+// ListServiceTypes returns the service types available to all projects
+func ListServiceTypes(client *aiven.Client) (map[string]ServiceType, error) {
+	var r struct {
+		ServiceTypes map[string]ServiceType `json:"service_types"`
+	}
+	if err := doGetRequest(client, buildPath("service_types"), &r); err != nil {
+		return nil, err
+	}
+
+	return r.ServiceTypes, nil
+}
+
+// ListIntegrationTypes returns the service integration types available in a project
+func ListIntegrationTypes(client *aiven.Client, project string) ([]IntegrationType, error) {
+	var r struct {
+		IntegrationTypes []IntegrationType `json:"integration_types"`
+	}
+	if err := doGetRequest(client, buildPath("project", project, "integration_types"), &r); err != nil {
+		return nil, err
+	}
+
+	return r.IntegrationTypes, nil
+}
+
+// ListIntegrationEndpointTypes returns the service integration endpoint types available in a project
+func ListIntegrationEndpointTypes(client *aiven.Client, project string) ([]IntegrationEndpointType, error) {
+	var r struct {
+		EndpointTypes []IntegrationEndpointType `json:"endpoint_types"`
+	}
+	if err := doGetRequest(client, buildPath("project", project, "integration_endpoint_types"), &r); err != nil {
+		return nil, err
+	}
+
+	return r.EndpointTypes, nil
+}
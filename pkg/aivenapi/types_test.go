@@ -0,0 +1,61 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aivenapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTestServer(t *testing.T, handler http.HandlerFunc) *aiven.Client {
+	server := httptest.NewServer(handler)
+	previousURL := apiURL
+	apiURL = server.URL + "/v1"
+	t.Cleanup(func() {
+		apiURL = previousURL
+		server.Close()
+	})
+
+	return &aiven.Client{APIKey: "token", Client: server.Client(), UserAgent: "test"}
+}
+
+func TestListServiceTypes(t *testing.T) {
+	client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/service_types", r.URL.Path)
+		assert.Equal(t, "aivenv1 token", r.Header.Get("Authorization"))
+		assert.Equal(t, "test", r.Header.Get("User-Agent"))
+		_, _ = w.Write([]byte(`{"service_types": {"pg": {"description": "PostgreSQL", "user_config_schema": {"type": "object"}}}}`))
+	})
+
+	got, err := ListServiceTypes(client)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]ServiceType{
+			"pg": {Description: "PostgreSQL", UserConfigSchema: map[string]interface{}{"type": "object"}},
+		}, got)
+	}
+}
+
+func TestListIntegrationTypes(t *testing.T) {
+	client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/project/my project/integration_types", r.URL.Path)
+		_, _ = w.Write([]byte(`{"integration_types": [{"integration_type": "logs", "dest_service_types": ["elasticsearch"]}]}`))
+	})
+
+	got, err := ListIntegrationTypes(client, "my project")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []IntegrationType{{IntegrationType: "logs", DestServiceTypes: []string{"elasticsearch"}}}, got)
+	}
+}
+
+func TestListIntegrationEndpointTypes_error(t *testing.T) {
+	client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Project does not exist"}`))
+	})
+
+	_, err := ListIntegrationEndpointTypes(client, "unknown")
+	assert.True(t, aiven.IsNotFound(err))
+}
@@ -5,39 +5,78 @@ package cache
 import (
 	"log"
 	"sync"
+	"time"
 
 	aiven "github.com/aiven/aiven-go-client"
 )
 
-var (
-	once       sync.Once
-	topicCache *TopicCache
+const (
+	// DefaultTopicCacheTTL is the time Kafka Topics are kept in the cache when no TTL is configured
+	DefaultTopicCacheTTL = 5 * time.Minute
+
+	// TopicPageSize is the maximum number of Kafka Topics retrieved with a single V2List call
+	TopicPageSize = 100
 )
 
-// TopicCache represents Kafka Topics cache based on Service and Project identifiers
-type TopicCache struct {
-	sync.RWMutex
-	internal map[string]map[string]aiven.KafkaTopic
-	inQueue  map[string][]string
+// KafkaTopicsClient is the part of the Aiven Kafka Topics API the cache uses to retrieve topics,
+// it is implemented by aiven.KafkaTopicsHandler
+type KafkaTopicsClient interface {
+	Get(project, service, topic string) (*aiven.KafkaTopic, error)
+	V2List(project, service string, topics []string) ([]*aiven.KafkaTopic, error)
 }
 
-// NewTopicCache creates new global instance of Kafka Topic Cache
-func NewTopicCache() *TopicCache {
-	log.Print("[DEBUG] Creating an instance of TopicCache ...")
+// serviceKey identifies the Kafka service the topics belong to
+type serviceKey struct {
+	projectName string
+	serviceName string
+}
 
-	once.Do(func() {
-		topicCache = &TopicCache{
-			internal: make(map[string]map[string]aiven.KafkaTopic),
-			inQueue:  make(map[string][]string),
-		}
-	})
+// cachedTopic is a Kafka Topic stored in the cache together with its expiration time
+type cachedTopic struct {
+	topic   aiven.KafkaTopic
+	expires time.Time
+}
 
-	return topicCache
+// isActive checks if the topic is ACTIVE and has not expired, the topics in the other states are
+// listed again on the next lookup so that their state changes are not hidden until they expire
+func (c cachedTopic) isActive(now time.Time) bool {
+	return c.topic.State == "ACTIVE" && !now.After(c.expires)
 }
 
-// GetTopicCache gets a global Kafka Topics Cache
-func GetTopicCache() *TopicCache {
-	return topicCache
+// refreshCall is an in-flight refresh of the queued topics of a service, it is shared by all the
+// lookups of the topics of the service until it is done
+type refreshCall struct {
+	done chan struct{}
+	err  error
+	// topicErrors contains the errors of the topics that cannot be retrieved, such as 404
+	topicErrors map[string]error
+}
+
+// TopicCache represents Kafka Topics cache based on Service and Project identifiers, every provider
+// instance has a cache of its own
+type TopicCache struct {
+	sync.RWMutex
+	client   KafkaTopicsClient
+	ttl      time.Duration
+	now      func() time.Time
+	internal map[serviceKey]map[string]cachedTopic
+	inQueue  map[serviceKey][]string
+	inFlight map[serviceKey]*refreshCall
+}
+
+// NewTopicCache creates a new Kafka Topic cache that retrieves the topics with the client and keeps
+// them for the ttl
+func NewTopicCache(client KafkaTopicsClient, ttl time.Duration) *TopicCache {
+	log.Printf("[DEBUG] Creating an instance of TopicCache with TTL %s ...", ttl)
+
+	return &TopicCache{
+		client:   client,
+		ttl:      ttl,
+		now:      time.Now,
+		internal: make(map[serviceKey]map[string]cachedTopic),
+		inQueue:  make(map[serviceKey][]string),
+		inFlight: make(map[serviceKey]*refreshCall),
+	}
 }
 
 // LoadByProjectAndServiceName returns a list of Kafka Topics stored in the cache for a given Project
@@ -45,39 +84,56 @@ func GetTopicCache() *TopicCache {
 // The ok result indicates whether value was found in the map.
 func (t *TopicCache) LoadByProjectAndServiceName(projectName, serviceName string) (map[string]aiven.KafkaTopic, bool) {
 	t.RLock()
-	result, ok := t.internal[projectName+serviceName]
-	t.RUnlock()
+	defer t.RUnlock()
+
+	now := t.now()
+	var result map[string]aiven.KafkaTopic
+	for name, c := range t.internal[serviceKey{projectName, serviceName}] {
+		if now.After(c.expires) {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]aiven.KafkaTopic)
+		}
+		result[name] = c.topic
+	}
 
-	return result, ok
+	return result, result != nil
 }
 
-// LoadByTopicName returns a list of Kafka Topics stored in the cache for a given Project
-// and Service names, or nil if no value is present.
-// The ok result indicates whether value was found in the map.
+// LoadByTopicName returns a Kafka Topic stored in the cache for a given Project, Service and Topic
+// names, a topic in the CONFIGURING state is returned if no unexpired value is present.
+// The ok result indicates whether an ACTIVE value was found in the map, a topic that is not ACTIVE
+// yet is returned as a miss so that it is refreshed.
 func (t *TopicCache) LoadByTopicName(projectName, serviceName, topicName string) (aiven.KafkaTopic, bool) {
 	t.RLock()
 	defer t.RUnlock()
 
-	topics, ok := t.internal[projectName+serviceName]
-	if !ok {
+	c, ok := t.internal[serviceKey{projectName, serviceName}][topicName]
+	if !ok || t.now().After(c.expires) {
 		return aiven.KafkaTopic{State: "CONFIGURING"}, false
 	}
-
-	result, ok := topics[topicName]
-	if !ok {
-		result.State = "CONFIGURING"
+	if !c.isActive(t.now()) {
+		return c.topic, false
 	}
 
-	log.Printf("[TRACE] retrienve from a topic cache `%+#v` for a topic name `%s`", result, topicName)
+	log.Printf("[TRACE] retrieve from a topic cache `%+#v` for a topic name `%s`", c.topic, topicName)
 
-	return result, ok
+	return c.topic, true
 }
 
-// DeleteByProjectAndServiceName deletes the cache value for a key which is a combination of Project
-// and Service names.
+// DeleteByProjectAndServiceName deletes the cached Kafka Topics of a Project and Service names.
 func (t *TopicCache) DeleteByProjectAndServiceName(projectName, serviceName string) {
 	t.Lock()
-	delete(t.internal, projectName+serviceName)
+	delete(t.internal, serviceKey{projectName, serviceName})
+	t.Unlock()
+}
+
+// DeleteByTopicName deletes a Kafka Topic from the cache, it is used to invalidate the topic when it
+// is created, updated or deleted.
+func (t *TopicCache) DeleteByTopicName(projectName, serviceName, topicName string) {
+	t.Lock()
+	delete(t.internal[serviceKey{projectName, serviceName}], topicName)
 	t.Unlock()
 }
 
@@ -89,62 +145,203 @@ func (t *TopicCache) StoreByProjectAndServiceName(projectName, serviceName strin
 
 	log.Printf("[DEBUG] Updating Kafka Topic cache for project %s and service %s ...", projectName, serviceName)
 
-	for _, topic := range list {
-		t.Lock()
-		if _, ok := t.internal[projectName+serviceName]; !ok {
-			t.internal[projectName+serviceName] = make(map[string]aiven.KafkaTopic)
-		}
-		t.internal[projectName+serviceName][topic.TopicName] = *topic
+	key := serviceKey{projectName, serviceName}
+	stored := make(map[string]bool, len(list))
 
-		// when topic is added to cache, it need to be deleted from the queue
-		for i, name := range t.inQueue[projectName+serviceName] {
-			if name == topic.TopicName {
-				t.inQueue[projectName+serviceName] = append(t.inQueue[projectName+serviceName][:i], t.inQueue[projectName+serviceName][i+1:]...)
-			}
-		}
+	t.Lock()
+	defer t.Unlock()
 
-		t.Unlock()
+	if _, ok := t.internal[key]; !ok {
+		t.internal[key] = make(map[string]cachedTopic)
+	}
+
+	expires := t.now().Add(t.ttl)
+	for _, topic := range list {
+		t.internal[key][topic.TopicName] = cachedTopic{topic: *topic, expires: expires}
+		stored[topic.TopicName] = true
 	}
+
+	// when topic is added to cache, it need to be deleted from the queue
+	t.removeFromQueue(key, stored)
 }
 
-// IsEmpty checks if cache is empty for particular service
+// IsQueueEmpty checks if the queue of topics to be found is empty for particular service
 func (t *TopicCache) IsQueueEmpty(projectName, serviceName string) bool {
 	t.RLock()
 	defer t.RUnlock()
 
-	_, ok := t.inQueue[projectName+serviceName]
-
-	return !ok
+	return len(t.inQueue[serviceKey{projectName, serviceName}]) == 0
 }
 
 // AddToQueue adds a topic name to a queue of topics to be found
 func (t *TopicCache) AddToQueue(projectName, serviceName, topicName string) {
-	var isFound bool
+	t.Lock()
+	t.addToQueue(serviceKey{projectName, serviceName}, topicName)
+	t.Unlock()
+}
+
+// GetQueue retrieves the first page of a topics queue, up to TopicPageSize elements
+func (t *TopicCache) GetQueue(projectName, serviceName string) []string {
+	t.RLock()
+	defer t.RUnlock()
+
+	queue := t.inQueue[serviceKey{projectName, serviceName}]
+	if len(queue) > TopicPageSize {
+		queue = queue[:TopicPageSize]
+	}
+
+	return append([]string(nil), queue...)
+}
+
+// Refresh adds topics to the queue of their service and waits until the queued topics of the service
+// are retrieved in pages of TopicPageSize topics and stored in the cache. Only one refresh of a
+// service is in flight at a time, the lookups of the other topics of the service join it instead of
+// listing the topics themselves. The error of the first topic that cannot be retrieved or of the
+// whole refresh is returned.
+func (t *TopicCache) Refresh(projectName, serviceName string, topicNames ...string) error {
+	key := serviceKey{projectName, serviceName}
 
 	t.Lock()
+	for _, name := range topicNames {
+		t.addToQueue(key, name)
+	}
+	call, inFlight := t.inFlight[key]
+	if !inFlight {
+		call = &refreshCall{done: make(chan struct{}), topicErrors: make(map[string]error)}
+		t.inFlight[key] = call
+	}
+	t.Unlock()
+
+	if inFlight {
+		log.Printf("[TRACE] Kafka Topic refresh of service %s/%s already in progress ...", projectName, serviceName)
+		<-call.done
+	} else {
+		t.drainQueue(key, call)
+		close(call.done)
+	}
+
+	for _, name := range topicNames {
+		if err, ok := call.topicErrors[name]; ok {
+			return err
+		}
+	}
+	return call.err
+}
+
+// drainQueue retrieves the queued topics of a service page by page until the queue is empty. When a
+// page cannot be retrieved, the whole queue is dropped and the error is shared by all the lookups
+// that joined the refresh, they queue their topics again on the next lookup.
+func (t *TopicCache) drainQueue(key serviceKey, call *refreshCall) {
+	for {
+		t.Lock()
+		queue := t.inQueue[key]
+		if len(queue) == 0 {
+			delete(t.inFlight, key)
+			t.Unlock()
+			return
+		}
+		if len(queue) > TopicPageSize {
+			queue = queue[:TopicPageSize]
+		}
+		queue = append([]string(nil), queue...)
+		t.Unlock()
+
+		log.Printf("[DEBUG] Kafka Topic queue: %+v", queue)
+		topics, topicErrors, err := t.list(key.projectName, key.serviceName, queue)
+		if err != nil {
+			t.Lock()
+			delete(t.inQueue, key)
+			delete(t.inFlight, key)
+			t.Unlock()
+
+			call.err = err
+			return
+		}
+
+		for name, err := range topicErrors {
+			call.topicErrors[name] = err
+		}
+
+		t.Lock()
+		t.removeFromQueue(key, toSet(queue))
+		t.Unlock()
+
+		t.StoreByProjectAndServiceName(key.projectName, key.serviceName, topics)
+	}
+}
+
+// list retrieves the topics with the v2 endpoint. The topics are retrieved one by one with the v1
+// endpoint when the v2 one is not available, or when one of the topics is not found so that the
+// other topics of the page can still be found.
+func (t *TopicCache) list(projectName, serviceName string, topicNames []string) ([]*aiven.KafkaTopic, map[string]error, error) {
+	topics, err := t.client.V2List(projectName, serviceName, topicNames)
+	if err == nil {
+		return topics, nil, nil
+	}
+
+	// if v2 endpoint retrieves 409 response code, it means that Kafka service has old nodes and
+	// v2 endpoint is not available, therefore using v1.
+	e, ok := err.(aiven.Error)
+	if !ok || (e.Status != 409 && e.Status != 404) {
+		return nil, nil, err
+	}
+
+	log.Printf("[DEBUG] Kafka Topics [%+v] cannot be listed with the V2 endpoint: %s, using v1!", topicNames, err)
+	topics = make([]*aiven.KafkaTopic, 0, len(topicNames))
+	topicErrors := make(map[string]error)
+	for _, name := range topicNames {
+		topic, err := t.client.Get(projectName, serviceName, name)
+		if err != nil {
+			if aiven.IsNotFound(err) {
+				topicErrors[name] = err
+				continue
+			}
+			return nil, nil, err
+		}
+		topics = append(topics, topic)
+	}
+
+	return topics, topicErrors, nil
+}
+
+// addToQueue adds a topic name to the queue of a service unless it is already queued or cached, the
+// caller must hold the lock
+func (t *TopicCache) addToQueue(key serviceKey, topicName string) {
 	// check if topic is already in the queue
-	for _, name := range t.inQueue[projectName+serviceName] {
+	for _, name := range t.inQueue[key] {
 		if name == topicName {
-			isFound = true
+			return
 		}
 	}
 
-	_, inCache := t.internal[projectName+serviceName][topicName]
-	// the only topic that is not in the queue nor inside cache can be added to the queue
-	if !isFound && !inCache {
-		t.inQueue[projectName+serviceName] = append(t.inQueue[projectName+serviceName], topicName)
+	// the only topic that is not in the queue nor ACTIVE inside cache can be added to the queue
+	if c, ok := t.internal[key][topicName]; ok && c.isActive(t.now()) {
+		return
 	}
-	t.Unlock()
+
+	t.inQueue[key] = append(t.inQueue[key], topicName)
 }
 
-// GetQueue retrieves a topics queue, retrieves up to 100 first elements
-func (t *TopicCache) GetQueue(projectName, serviceName string) []string {
-	t.RLock()
-	defer t.RUnlock()
+// removeFromQueue removes topic names from the queue of a service, the caller must hold the lock
+func (t *TopicCache) removeFromQueue(key serviceKey, names map[string]bool) {
+	queue := t.inQueue[key][:0]
+	for _, name := range t.inQueue[key] {
+		if !names[name] {
+			queue = append(queue, name)
+		}
+	}
 
-	if len(t.inQueue[projectName+serviceName]) >= 100 {
-		return t.inQueue[projectName+serviceName][:99]
+	if len(queue) == 0 {
+		delete(t.inQueue, key)
+		return
 	}
+	t.inQueue[key] = queue
+}
 
-	return t.inQueue[projectName+serviceName]
+func toSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
 }
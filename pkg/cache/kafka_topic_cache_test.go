@@ -3,58 +3,41 @@
 package cache
 
 import (
+	"fmt"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/aiven/aiven-go-client"
 )
 
+// testTopicCache is the cache the table tests use, it is recreated by each test case setup
+var testTopicCache *TopicCache
+
 func setupTopicCacheTestCase(t *testing.T) func(t *testing.T) {
 	t.Log("setup Kafka Topic Cache test case")
 
-	if GetTopicCache() == nil {
-		_ = NewTopicCache()
-	}
+	testTopicCache = NewTopicCache(newFakeTopicsClient(), DefaultTopicCacheTTL)
 
 	return func(t *testing.T) {
 		t.Log("teardown Kafka Topic Cache test case")
 
 		// clean topic cache after each test
-		topicCache.internal = make(map[string]map[string]aiven.KafkaTopic)
+		testTopicCache = nil
 	}
 }
 
-func TestGetTopicCache(t *testing.T) {
-	tests := []struct {
-		name string
-		init func()
-		want *TopicCache
-	}{
-		{
-			"not_initialized",
-			func() {
-			},
-			nil,
-		},
-		{
-			"initialized",
-			func() {
-				_ = NewTopicCache()
-			},
-			&TopicCache{
-				internal: make(map[string]map[string]aiven.KafkaTopic),
-				inQueue:  make(map[string][]string),
-			},
-		},
+func TestNewTopicCache(t *testing.T) {
+	c1 := NewTopicCache(newFakeTopicsClient(), time.Minute)
+	c2 := NewTopicCache(newFakeTopicsClient(), time.Minute)
+	if c1 == c2 {
+		t.Fatal("NewTopicCache() should create a new cache for every provider instance")
 	}
-	for _, tt := range tests {
-		tt.init()
 
-		t.Run(tt.name, func(t *testing.T) {
-			if got := GetTopicCache(); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("GetTopicCache() = %v, want %v", got, tt.want)
-			}
-		})
+	c1.StoreByProjectAndServiceName("test-pr1", "test-sr1", []*aiven.KafkaTopic{{TopicName: "topic-1", State: "ACTIVE"}})
+	if _, ok := c2.LoadByTopicName("test-pr1", "test-sr1", "topic-1"); ok {
+		t.Error("topics stored in one cache should not be visible in another one")
 	}
 }
 
@@ -94,19 +77,19 @@ func TestTopicCache_LoadByProjectAndServiceName(t1 *testing.T) {
 			map[string]aiven.KafkaTopic{
 				"topic-1": {
 					Replication: 3,
-					State:       "AVAILABLE",
+					State:       "ACTIVE",
 					TopicName:   "topic-1",
 				},
 				"topic-2": {
 					Replication: 1,
-					State:       "AVAILABLE",
+					State:       "ACTIVE",
 					TopicName:   "topic-2",
 				},
 			},
 			true,
 		},
 	}
-	t := GetTopicCache()
+	t := testTopicCache
 	for _, tt := range tests {
 		tt.doSomething()
 
@@ -163,13 +146,13 @@ func TestTopicCache_LoadByTopicName(t1 *testing.T) {
 			},
 			aiven.KafkaTopic{
 				Replication: 3,
-				State:       "AVAILABLE",
+				State:       "ACTIVE",
 				TopicName:   "topic-1",
 			},
 			true,
 		},
 	}
-	t := GetTopicCache()
+	t := testTopicCache
 	for _, tt := range tests {
 		tt.doSomething()
 
@@ -207,7 +190,7 @@ func TestTopicCache_DeleteByProjectAndServiceName(t1 *testing.T) {
 			},
 		},
 	}
-	t := GetTopicCache()
+	t := testTopicCache
 	for _, tt := range tests {
 		tt.doSomething()
 
@@ -234,20 +217,335 @@ func TestTopicCache_DeleteByProjectAndServiceName(t1 *testing.T) {
 }
 
 func testAddTwoTopicsToCache() {
-	cache := GetTopicCache()
-	cache.StoreByProjectAndServiceName(
+	testTopicCache.StoreByProjectAndServiceName(
 		"test-pr1",
 		"test-sr1",
 		[]*aiven.KafkaTopic{
 			{
 				Replication: 3,
-				State:       "AVAILABLE",
+				State:       "ACTIVE",
 				TopicName:   "topic-1",
 			},
 			{
 				Replication: 1,
-				State:       "AVAILABLE",
+				State:       "ACTIVE",
 				TopicName:   "topic-2",
 			},
 		})
 }
+
+func TestTopicCache_StructuredKeys(t *testing.T) {
+	c := NewTopicCache(newFakeTopicsClient(), DefaultTopicCacheTTL)
+	c.StoreByProjectAndServiceName("ab", "c", []*aiven.KafkaTopic{{TopicName: "topic-1", State: "ACTIVE"}})
+
+	if _, ok := c.LoadByTopicName("a", "bc", "topic-1"); ok {
+		t.Error("project `a` and service `bc` should not share the topics of project `ab` and service `c`")
+	}
+	if _, ok := c.LoadByTopicName("ab", "c", "topic-1"); !ok {
+		t.Error("topic-1 should be found for project `ab` and service `c`")
+	}
+}
+
+func TestTopicCache_TTL(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewTopicCache(newFakeTopicsClient(), time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.StoreByProjectAndServiceName("test-pr1", "test-sr1", []*aiven.KafkaTopic{{TopicName: "topic-1", State: "ACTIVE"}})
+
+	now = now.Add(time.Minute)
+	if _, ok := c.LoadByTopicName("test-pr1", "test-sr1", "topic-1"); !ok {
+		t.Error("topic-1 should not expire before the TTL has passed")
+	}
+
+	now = now.Add(time.Second)
+	if got, ok := c.LoadByTopicName("test-pr1", "test-sr1", "topic-1"); ok || got.State != "CONFIGURING" {
+		t.Errorf("topic-1 should expire after the TTL, got = %v, %v", got, ok)
+	}
+	if got, ok := c.LoadByProjectAndServiceName("test-pr1", "test-sr1"); ok {
+		t.Errorf("expired topics should not be returned, got = %v", got)
+	}
+
+	c.AddToQueue("test-pr1", "test-sr1", "topic-1")
+	if c.IsQueueEmpty("test-pr1", "test-sr1") {
+		t.Error("an expired topic should be added to the queue")
+	}
+}
+
+func TestTopicCache_DeleteByTopicName(t *testing.T) {
+	c := NewTopicCache(newFakeTopicsClient(), DefaultTopicCacheTTL)
+	c.StoreByProjectAndServiceName("test-pr1", "test-sr1", []*aiven.KafkaTopic{
+		{TopicName: "topic-1", State: "ACTIVE"},
+		{TopicName: "topic-2", State: "ACTIVE"},
+	})
+
+	c.DeleteByTopicName("test-pr1", "test-sr1", "topic-1")
+	c.DeleteByTopicName("test-pr2", "test-sr2", "topic-1")
+
+	if _, ok := c.LoadByTopicName("test-pr1", "test-sr1", "topic-1"); ok {
+		t.Error("topic-1 should be deleted from the cache")
+	}
+	if _, ok := c.LoadByTopicName("test-pr1", "test-sr1", "topic-2"); !ok {
+		t.Error("topic-2 should remain in the cache")
+	}
+}
+
+func TestTopicCache_GetQueue(t *testing.T) {
+	c := NewTopicCache(newFakeTopicsClient(), DefaultTopicCacheTTL)
+	for i := 0; i < 250; i++ {
+		c.AddToQueue("test-pr1", "test-sr1", fmt.Sprintf("topic-%d", i))
+	}
+	c.AddToQueue("test-pr1", "test-sr1", "topic-0")
+
+	var pages []int
+	for !c.IsQueueEmpty("test-pr1", "test-sr1") {
+		queue := c.GetQueue("test-pr1", "test-sr1")
+		pages = append(pages, len(queue))
+
+		var topics []*aiven.KafkaTopic
+		for _, name := range queue {
+			topics = append(topics, &aiven.KafkaTopic{TopicName: name, State: "ACTIVE"})
+		}
+		c.StoreByProjectAndServiceName("test-pr1", "test-sr1", topics)
+	}
+
+	if want := []int{100, 100, 50}; !reflect.DeepEqual(pages, want) {
+		t.Errorf("GetQueue() pages = %v, want %v", pages, want)
+	}
+}
+
+func TestTopicCache_Refresh(t *testing.T) {
+	client := newFakeTopicsClient()
+	client.addTopics("test-pr1", "test-sr1", 150)
+	c := NewTopicCache(client, DefaultTopicCacheTTL)
+
+	for i := 1; i < 150; i++ {
+		c.AddToQueue("test-pr1", "test-sr1", fmt.Sprintf("topic-%d", i))
+	}
+	if err := c.Refresh("test-pr1", "test-sr1", "topic-0"); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if want := []int{100, 50}; !reflect.DeepEqual(client.pages(), want) {
+		t.Errorf("V2List() pages = %v, want %v", client.pages(), want)
+	}
+	if got, _ := c.LoadByProjectAndServiceName("test-pr1", "test-sr1"); len(got) != 150 {
+		t.Errorf("LoadByProjectAndServiceName() got %d topics, want 150", len(got))
+	}
+
+	// a cached topic is not listed again
+	if err := c.Refresh("test-pr1", "test-sr1", "topic-0"); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if got := len(client.pages()); got != 2 {
+		t.Errorf("V2List() called %d times, want 2", got)
+	}
+}
+
+func TestTopicCache_RefreshNotFound(t *testing.T) {
+	client := newFakeTopicsClient()
+	client.addTopics("test-pr1", "test-sr1", 2)
+	c := NewTopicCache(client, DefaultTopicCacheTTL)
+
+	c.AddToQueue("test-pr1", "test-sr1", "topic-0")
+	c.AddToQueue("test-pr1", "test-sr1", "missing")
+
+	if err := c.Refresh("test-pr1", "test-sr1", "topic-1"); err != nil {
+		t.Errorf("Refresh() of an existing topic error = %v, want nil", err)
+	}
+	if _, ok := c.LoadByTopicName("test-pr1", "test-sr1", "topic-0"); !ok {
+		t.Error("the topics of a page with a missing topic should still be found")
+	}
+	if !c.IsQueueEmpty("test-pr1", "test-sr1") {
+		t.Error("the topics that cannot be retrieved should be removed from the queue")
+	}
+
+	if err := c.Refresh("test-pr1", "test-sr1", "missing"); !aiven.IsNotFound(err) {
+		t.Errorf("Refresh() error = %v, want not found", err)
+	}
+}
+
+func TestTopicCache_RefreshError(t *testing.T) {
+	client := newFakeTopicsClient()
+	client.addTopics("test-pr1", "test-sr1", 2)
+	client.err = aiven.Error{Status: 502, Message: "Bad Gateway"}
+	c := NewTopicCache(client, DefaultTopicCacheTTL)
+
+	c.AddToQueue("test-pr1", "test-sr1", "topic-0")
+	if err := c.Refresh("test-pr1", "test-sr1", "topic-1"); err == nil {
+		t.Error("Refresh() error = nil, want the error of the API")
+	}
+	if !c.IsQueueEmpty("test-pr1", "test-sr1") {
+		t.Error("the queue should be dropped when the topics cannot be listed")
+	}
+}
+
+func TestTopicCache_RefreshCoalesced(t *testing.T) {
+	client := newFakeTopicsClient()
+	client.addTopics("test-pr1", "test-sr1", 1000)
+	client.addTopics("test-pr1", "test-sr2", 1000)
+	client.latency = time.Millisecond
+	c := NewTopicCache(client, DefaultTopicCacheTTL)
+
+	var wg sync.WaitGroup
+	for _, service := range []string{"test-sr1", "test-sr2"} {
+		for i := 0; i < 1000; i++ {
+			wg.Add(1)
+			go func(service, topic string) {
+				defer wg.Done()
+
+				if err := c.Refresh("test-pr1", service, topic); err != nil {
+					t.Errorf("Refresh() error = %v", err)
+				}
+				if _, ok := c.LoadByTopicName("test-pr1", service, topic); !ok {
+					t.Errorf("topic %s of service %s should be cached after the refresh", topic, service)
+				}
+			}(service, fmt.Sprintf("topic-%d", i))
+		}
+	}
+	wg.Wait()
+
+	if client.maxInFlight > 1 {
+		t.Errorf("V2List() of a service was called %d times concurrently, want 1", client.maxInFlight)
+	}
+	var listed int
+	for _, n := range client.pages() {
+		listed += n
+	}
+	if listed != 2000 {
+		t.Errorf("V2List() listed %d topics, want every topic listed once", listed)
+	}
+}
+
+func TestTopicCache_RefreshConfiguring(t *testing.T) {
+	client := newFakeTopicsClient()
+	client.addTopics("test-pr1", "test-sr1", 1)
+	client.configuringLists = 1
+	c := NewTopicCache(client, DefaultTopicCacheTTL)
+
+	if err := c.Refresh("test-pr1", "test-sr1", "topic-0"); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if got, ok := c.LoadByTopicName("test-pr1", "test-sr1", "topic-0"); ok || got.State != "CONFIGURING" {
+		t.Errorf("a CONFIGURING topic should be a miss, got = %v, %v", got, ok)
+	}
+
+	// the CONFIGURING topic is listed again before its TTL has passed
+	if err := c.Refresh("test-pr1", "test-sr1", "topic-0"); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if got, ok := c.LoadByTopicName("test-pr1", "test-sr1", "topic-0"); !ok || got.State != "ACTIVE" {
+		t.Errorf("the topic should be ACTIVE after the second refresh, got = %v, %v", got, ok)
+	}
+	if want := []int{1, 1}; !reflect.DeepEqual(client.pages(), want) {
+		t.Errorf("V2List() pages = %v, want %v", client.pages(), want)
+	}
+}
+
+func TestTopicCache_RefreshV1(t *testing.T) {
+	client := newFakeTopicsClient()
+	client.addTopics("test-pr1", "test-sr1", 3)
+	client.v2Unavailable = true
+	c := NewTopicCache(client, DefaultTopicCacheTTL)
+
+	c.AddToQueue("test-pr1", "test-sr1", "topic-0")
+	if err := c.Refresh("test-pr1", "test-sr1", "topic-2"); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if got, _ := c.LoadByProjectAndServiceName("test-pr1", "test-sr1"); len(got) != 2 {
+		t.Errorf("LoadByProjectAndServiceName() got %d topics, want 2", len(got))
+	}
+}
+
+// fakeTopicsClient is a KafkaTopicsClient that serves the topics it contains and records the
+// V2List calls and the most calls of a service in flight at a time, the topics are CONFIGURING for
+// their first configuringLists listings
+type fakeTopicsClient struct {
+	sync.Mutex
+	topics           map[string]bool
+	configuringLists int
+	listed           map[string]int
+	v2Unavailable    bool
+	err              error
+	latency          time.Duration
+	calls            [][]string
+	inFlight         map[string]int
+	maxInFlight      int
+}
+
+func newFakeTopicsClient() *fakeTopicsClient {
+	return &fakeTopicsClient{topics: make(map[string]bool), listed: make(map[string]int), inFlight: make(map[string]int)}
+}
+
+func (c *fakeTopicsClient) addTopics(project, service string, n int) {
+	c.Lock()
+	defer c.Unlock()
+
+	for i := 0; i < n; i++ {
+		c.topics[project+"/"+service+"/"+fmt.Sprintf("topic-%d", i)] = true
+	}
+}
+
+func (c *fakeTopicsClient) pages() []int {
+	c.Lock()
+	defer c.Unlock()
+
+	var pages []int
+	for _, call := range c.calls {
+		pages = append(pages, len(call))
+	}
+	return pages
+}
+
+func (c *fakeTopicsClient) Get(project, service, topic string) (*aiven.KafkaTopic, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.topics[project+"/"+service+"/"+topic] {
+		return nil, aiven.Error{Status: 404, Message: "Topic not found"}
+	}
+	return &aiven.KafkaTopic{TopicName: topic, State: "ACTIVE"}, nil
+}
+
+func (c *fakeTopicsClient) V2List(project, service string, topics []string) ([]*aiven.KafkaTopic, error) {
+	c.Lock()
+	c.inFlight[project+"/"+service]++
+	if c.inFlight[project+"/"+service] > c.maxInFlight {
+		c.maxInFlight = c.inFlight[project+"/"+service]
+	}
+	c.Unlock()
+
+	time.Sleep(c.latency)
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.inFlight[project+"/"+service]--
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.v2Unavailable {
+		return nil, aiven.Error{Status: 409, Message: "V2 endpoint is not available"}
+	}
+
+	c.calls = append(c.calls, topics)
+	var result []*aiven.KafkaTopic
+	for _, name := range topics {
+		if !c.topics[project+"/"+service+"/"+name] {
+			return nil, aiven.Error{Status: 404, Message: "Topic not found"}
+		}
+		result = append(result, &aiven.KafkaTopic{TopicName: name, State: c.state(project, service, name)})
+	}
+	return result, nil
+}
+
+// state returns the state of a listed topic, the caller must hold the lock
+func (c *fakeTopicsClient) state(project, service, topic string) string {
+	key := project + "/" + service + "/" + topic
+	c.listed[key]++
+	if c.listed[key] <= c.configuringLists {
+		return "CONFIGURING"
+	}
+	return "ACTIVE"
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/docker/go-units"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// HoursPerMonth is the number of hours the monthly prices are based on
+const HoursPerMonth = 730
+
+// EstimatedMonthlyCostUSD returns the monthly cost of a service in USD, which is the base price of
+// the plan in the cloud and the price of the disk space above the default disk space of the plan.
+// The pricing is shared with the check of the disk space through the plan cache of the meta.
+func EstimatedMonthlyCostUSD(m interface{}, client *aiven.Client, project string, s *aiven.Service, defaultDiskSpaceMB int) (float64, error) {
+	pricing, err := getPlanCache(m).Pricing(client, project, s.Type, s.Plan, s.CloudName)
+	if err != nil {
+		return 0, err
+	}
+
+	return monthlyCostUSD(pricing.BasePriceUSD, pricing.ExtraDiskPricePerGBUSD, s.DiskSpaceMB, defaultDiskSpaceMB)
+}
+
+// estimateMonthlyCostUSD returns the monthly cost of a service in USD when the default disk space
+// of the plan is not known
+func estimateMonthlyCostUSD(planCache *PlanCache, client *aiven.Client, project, serviceType, servicePlan, cloudName string, diskSpaceMB int) (float64, error) {
+	planParams, err := planCache.Parameters(client, project, serviceType, servicePlan)
+	if err != nil {
+		return 0, err
+	}
+	pricing, err := planCache.Pricing(client, project, serviceType, servicePlan, cloudName)
+	if err != nil {
+		return 0, err
+	}
+
+	return monthlyCostUSD(pricing.BasePriceUSD, pricing.ExtraDiskPricePerGBUSD, diskSpaceMB, planParams.DiskSizeMBDefault)
+}
+
+// monthlyCostUSD calculates the monthly cost from the hourly prices of the pricing API, rounded
+// to cents
+func monthlyCostUSD(basePriceUSD, extraDiskPricePerGBUSD string, diskSpaceMB, defaultDiskSpaceMB int) (float64, error) {
+	basePrice, err := strconv.ParseFloat(basePriceUSD, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid base price %q: %w", basePriceUSD, err)
+	}
+
+	hourly := basePrice
+	if extraDiskMB := diskSpaceMB - defaultDiskSpaceMB; extraDiskMB > 0 && extraDiskPricePerGBUSD != "" {
+		extraDiskPrice, err := strconv.ParseFloat(extraDiskPricePerGBUSD, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid extra disk price %q: %w", extraDiskPricePerGBUSD, err)
+		}
+		hourly += extraDiskPrice * float64(extraDiskMB) / float64(units.KiB)
+	}
+
+	return math.Round(hourly*HoursPerMonth*100) / 100, nil
+}
+
+// ProjectEstimatedMonthlyCostUSD returns the sum of the estimated monthly costs of the services of
+// a project that are powered on, the plans and the pricing are shared through the plan cache of the
+// meta by the services that use the same plan
+func ProjectEstimatedMonthlyCostUSD(m interface{}, client *aiven.Client, project string) (float64, error) {
+	services, err := client.Services.List(project)
+	if err != nil {
+		return 0, fmt.Errorf("unable to list the services of project %s: %w", project, err)
+	}
+
+	planCache := getPlanCache(m)
+	if planCache == nil {
+		planCache = &PlanCache{}
+	}
+
+	var total float64
+	for _, s := range services {
+		if !s.Powered {
+			continue
+		}
+
+		cost, err := estimateMonthlyCostUSD(planCache, client, project, s.Type, s.Plan, s.CloudName, s.DiskSpaceMB)
+		if err != nil {
+			return 0, fmt.Errorf("unable to estimate the monthly cost of service %s: %w", s.Name, err)
+		}
+		total += cost
+	}
+
+	return math.Round(total*100) / 100, nil
+}
+
+// CustomizeDiffEstimatedMonthlyCost sets the new `estimated_monthly_cost_usd` of a service when its
+// plan, cloud, disk space or power state changes, so that the plan shows the cost before and after
+// the change. The cost is left unknown when it cannot be estimated, which does not fail the plan.
+func CustomizeDiffEstimatedMonthlyCost(serviceType string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+		client, ok := getClient(m)
+		if !ok || client == nil {
+			return nil
+		}
+		if d.Id() != "" && !d.HasChange("plan") && !d.HasChange("cloud_name") && !d.HasChange("disk_space") && !d.HasChange("powered") {
+			return nil
+		}
+		keys := []string{"project", "plan", "cloud_name", "disk_space", "powered"}
+		if serviceType == "" {
+			keys = append(keys, "service_type")
+		}
+		for _, k := range keys {
+			if !d.NewValueKnown(k) {
+				return d.SetNewComputed("estimated_monthly_cost_usd")
+			}
+		}
+
+		if powered, ok := d.Get("powered").(bool); ok && !powered {
+			return d.SetNew("estimated_monthly_cost_usd", 0.0)
+		}
+
+		serviceType := serviceType
+		if serviceType == "" {
+			serviceType = d.Get("service_type").(string)
+		}
+		project := d.Get("project").(string)
+		servicePlan := d.Get("plan").(string)
+		cloudName := d.Get("cloud_name").(string)
+		if serviceType == "" || servicePlan == "" || cloudName == "" {
+			return d.SetNewComputed("estimated_monthly_cost_usd")
+		}
+
+		var diskSpaceMB int
+		if ds, ok := d.Get("disk_space").(string); ok && ds != "" {
+			diskSpaceMB = ConvertToDiskSpaceMB(ds)
+		}
+
+		cost, err := estimateMonthlyCostUSD(getPlanCache(m), client, project, serviceType, servicePlan, cloudName, diskSpaceMB)
+		if err != nil {
+			log.Printf("[WARN] unable to estimate the monthly cost of the service: %s", err)
+			return d.SetNewComputed("estimated_monthly_cost_usd")
+		}
+
+		return d.SetNew("estimated_monthly_cost_usd", cost)
+	}
+}
+
+// EstimatedMonthlyCostChangeSummary returns the summary of the warning about the change of the
+// estimated monthly cost of a service, an empty string is returned when the cost does not change
+func EstimatedMonthlyCostChangeSummary(serviceName string, before, after float64) string {
+	if before == after {
+		return ""
+	}
+
+	return fmt.Sprintf("The estimated monthly cost of service %s changes from %.2f USD to %.2f USD (%+.2f USD)",
+		serviceName, before, after, after-before)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonthlyCostUSD(t *testing.T) {
+	tests := []struct {
+		name                   string
+		basePriceUSD           string
+		extraDiskPricePerGBUSD string
+		diskSpaceMB            int
+		want                   float64
+		wantErr                bool
+	}{
+		{name: "base price", basePriceUSD: "0.2740", extraDiskPricePerGBUSD: "0.000150", diskSpaceMB: 81920, want: 200.02},
+		{name: "default disk space", basePriceUSD: "0.2740", extraDiskPricePerGBUSD: "0.000150", want: 200.02},
+		{name: "extra disk", basePriceUSD: "0.2740", extraDiskPricePerGBUSD: "0.000150", diskSpaceMB: 81920 + 100*1024, want: 210.97},
+		{name: "extra disk not priced", basePriceUSD: "0.0260", diskSpaceMB: 81920 + 100*1024, want: 18.98},
+		{name: "invalid base price", basePriceUSD: "", wantErr: true},
+		{name: "invalid extra disk price", basePriceUSD: "0.2740", extraDiskPricePerGBUSD: "x", diskSpaceMB: 90000, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := monthlyCostUSD(tt.basePriceUSD, tt.extraDiskPricePerGBUSD, tt.diskSpaceMB, 81920)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEstimatedMonthlyCostChangeSummary(t *testing.T) {
+	assert.Equal(t, "", EstimatedMonthlyCostChangeSummary("pg", 200.02, 200.02))
+	assert.Equal(t, "The estimated monthly cost of service pg changes from 200.02 USD to 400.04 USD (+200.02 USD)",
+		EstimatedMonthlyCostChangeSummary("pg", 200.02, 400.04))
+	assert.Equal(t, "The estimated monthly cost of service pg changes from 400.04 USD to 0.00 USD (-400.04 USD)",
+		EstimatedMonthlyCostChangeSummary("pg", 400.04, 0))
+}
@@ -6,7 +6,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
 	"github.com/docker/go-units"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -27,13 +27,18 @@ func DiskSpaceShouldNotBeEmpty(_ context.Context, _, new, _ interface{}) bool {
 }
 
 func CustomizeDiffCheckDiskSpace(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
-	client := m.(*aiven.Client)
+	client := m.(ClientProvider).AivenClient()
 
 	if d.Get("service_type").(string) == "" {
 		return fmt.Errorf("cannot check dynamic disc space because service_type is empty")
 	}
 
-	servicePlanParams, err := GetServicePlanParametersFromSchema(ctx, client, d)
+	planCache := getPlanCache(m)
+	project := d.Get("project").(string)
+	serviceType := d.Get("service_type").(string)
+	servicePlan := d.Get("plan").(string)
+
+	servicePlanParams, err := planCache.Parameters(client, project, serviceType, servicePlan)
 	if err != nil {
 		return fmt.Errorf("unable to get service plan parameters: %w", err)
 	}
@@ -53,9 +58,11 @@ func CustomizeDiffCheckDiskSpace(ctx context.Context, d *schema.ResourceDiff, m
 		}
 
 		// next check if the cloud allows it by checking the pricing per gb
-		if ok, err := dynamicDiskSpaceIsAllowedByPricing(ctx, client, d); err != nil {
+		pricing, err := planCache.Pricing(client, project, serviceType, servicePlan, d.Get("cloud_name").(string))
+		if err != nil {
 			return fmt.Errorf("unable to check if dynamic disk space is allowed for this service: %w", err)
-		} else if !ok {
+		}
+		if !dynamicDiskSpaceIsAllowedByPricing(pricing) {
 			return fmt.Errorf("dynamic disk space is not configurable for this service")
 		}
 	}
@@ -90,3 +97,83 @@ func SetServiceTypeIfEmpty(t string) schema.CustomizeDiffFunc {
 		return nil
 	}
 }
+
+// CustomizeDiffCheckPlan fails the plan when the service plan does not exist for the service type,
+// is not available in the cloud of the service, or when changing the plan would reduce the number of
+// nodes or the disk space below the disk space the service uses. The service type of the resource
+// is read from the `service_type` attribute when it is empty.
+func CustomizeDiffCheckPlan(serviceType string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+		client, ok := getClient(m)
+		if !ok || client == nil {
+			return nil
+		}
+		if d.Id() != "" && !d.HasChange("plan") && !d.HasChange("cloud_name") && !d.HasChange("disk_space") {
+			return nil
+		}
+		keys := []string{"project", "plan", "cloud_name", "disk_space"}
+		if serviceType == "" {
+			keys = append(keys, "service_type")
+		}
+		for _, k := range keys {
+			if !d.NewValueKnown(k) {
+				return nil
+			}
+		}
+
+		serviceType := serviceType
+		if serviceType == "" {
+			serviceType = d.Get("service_type").(string)
+		}
+		project := d.Get("project").(string)
+		planName := d.Get("plan").(string)
+		cloudName := d.Get("cloud_name").(string)
+		if serviceType == "" || planName == "" {
+			return nil
+		}
+
+		plans, err := aivenapi.ListServicePlans(client, project, serviceType)
+		if err != nil {
+			return fmt.Errorf("cannot list the plans of service type %s: %w", serviceType, err)
+		}
+
+		plan := findServicePlan(plans, planName)
+		if plan == nil {
+			return fmt.Errorf("plan: %s is not a plan of service type %s%s",
+				planName, serviceType, closeMatchesMessage(planName, servicePlanNames(plans, "")))
+		}
+
+		if _, ok := plan.Regions[cloudName]; cloudName != "" && len(plan.Regions) > 0 && !ok {
+			return fmt.Errorf("plan: %s is not available in cloud %s%s",
+				planName, cloudName, closeMatchesMessage(planName, servicePlanNames(plans, cloudName)))
+		}
+
+		if d.Id() == "" {
+			return nil
+		}
+
+		if oldPlanName, _ := d.GetChange("plan"); oldPlanName.(string) != planName {
+			if oldPlan := findServicePlan(plans, oldPlanName.(string)); oldPlan != nil && plan.NodeCount < oldPlan.NodeCount {
+				return fmt.Errorf("plan: changing the plan from %s to %s would reduce the number of nodes from %d to %d, "+
+					"which is not allowed, choose a plan with at least %d nodes",
+					oldPlan.ServicePlan, planName, oldPlan.NodeCount, plan.NodeCount, oldPlan.NodeCount)
+			}
+		}
+
+		usedDiskSpaceMB := 0
+		if used, ok := d.Get("disk_space_used").(string); ok && used != "" {
+			usedDiskSpaceMB = ConvertToDiskSpaceMB(used)
+		}
+		diskSpaceMB := servicePlanDiskSpaceMB(plan, cloudName)
+		if ds, ok := d.Get("disk_space").(string); ok && ds != "" {
+			diskSpaceMB = ConvertToDiskSpaceMB(ds)
+		}
+		if diskSpaceMB > 0 && diskSpaceMB < usedDiskSpaceMB {
+			return fmt.Errorf("plan: the disk space of the service would shrink to '%s', which is less than the "+
+				"'%s' the service uses, increase `disk_space` or choose a plan with more disk space",
+				HumanReadableByteSize(diskSpaceMB*units.MiB), HumanReadableByteSize(usedDiskSpaceMB*units.MiB))
+		}
+
+		return nil
+	}
+}
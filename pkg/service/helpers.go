@@ -5,9 +5,11 @@ package service
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
 	"github.com/docker/go-units"
 )
 
@@ -92,20 +94,10 @@ func getServicePlanParametersInternal(_ context.Context, client *aiven.Client, p
 	}, nil
 }
 
-func dynamicDiskSpaceIsAllowedByPricing(_ context.Context, client *aiven.Client, d ResourceStateOrResourceDiff) (bool, error) {
+func dynamicDiskSpaceIsAllowedByPricing(pricing *aiven.GetServicePlanPricingResponse) bool {
 	// to check if dynamic disk space is allowed, we currently have to check
 	// the pricing api to see if the `extra_disk_price_per_gb_usd` field is set
-
-	project := d.Get("project").(string)
-	serviceType := d.Get("service_type").(string)
-	servicePlan := d.Get("plan").(string)
-	cloudName := d.Get("cloud_name").(string)
-
-	servicePlanPricingResponse, err := client.ServiceTypes.GetPlanPricing(project, serviceType, servicePlan, cloudName)
-	if err != nil {
-		return false, fmt.Errorf("unable to get service plan pricing from api: %w", err)
-	}
-	return len(servicePlanPricingResponse.ExtraDiskPricePerGBUSD) > 0, nil
+	return len(pricing.ExtraDiskPricePerGBUSD) > 0
 }
 
 func HumanReadableByteSize(s int) string {
@@ -115,3 +107,118 @@ func HumanReadableByteSize(s int) string {
 
 	return units.CustomSize("%.12g%s", float64(s), 1024.0, suffixes)
 }
+
+// findServicePlan returns the plan with the name, nil is returned when it is not in the list
+func findServicePlan(plans []aivenapi.ServicePlan, name string) *aivenapi.ServicePlan {
+	for i := range plans {
+		if plans[i].ServicePlan == name {
+			return &plans[i]
+		}
+	}
+	return nil
+}
+
+// servicePlanNames returns the names of the plans, only the plans available in the cloud are
+// returned when the cloud name is not empty
+func servicePlanNames(plans []aivenapi.ServicePlan, cloudName string) []string {
+	var names []string
+	for _, p := range plans {
+		if _, ok := p.Regions[cloudName]; cloudName == "" || ok {
+			names = append(names, p.ServicePlan)
+		}
+	}
+	return names
+}
+
+// servicePlanDiskSpaceMB returns the default disk space of a plan in a cloud
+func servicePlanDiskSpaceMB(plan *aivenapi.ServicePlan, cloudName string) int {
+	if region, ok := plan.Regions[cloudName]; ok && region.DiskSpaceMB > 0 {
+		return region.DiskSpaceMB
+	}
+	return plan.DiskSpaceMB
+}
+
+// maxCloseMatches is the number of close matches listed in the errors
+const maxCloseMatches = 5
+
+// closeMatchesMessage returns the part of an error message that lists the candidates closest to a
+// value, such as ", did you mean business-4 or business-8?"
+func closeMatchesMessage(value string, candidates []string) string {
+	matches := closeMatches(value, candidates, maxCloseMatches)
+	switch len(matches) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf(", did you mean %s?", matches[0])
+	default:
+		return fmt.Sprintf(", did you mean %s or %s?",
+			strings.Join(matches[:len(matches)-1], ", "), matches[len(matches)-1])
+	}
+}
+
+// closeMatches returns at most limit candidates ordered by their edit distance to the value, the
+// candidates that differ from the value in more than half of the characters are left out
+func closeMatches(value string, candidates []string, limit int) []string {
+	type match struct {
+		name     string
+		distance int
+	}
+
+	var matches []match
+	for _, c := range candidates {
+		distance := editDistance(value, c)
+		longest := len(value)
+		if len(c) > longest {
+			longest = len(c)
+		}
+		if distance <= longest/2 {
+			matches = append(matches, match{name: c, distance: distance})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	var result []string
+	for i := 0; i < len(matches) && i < limit; i++ {
+		result = append(result, matches[i].name)
+	}
+	return result
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
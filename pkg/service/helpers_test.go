@@ -0,0 +1,49 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+
+package service
+
+import (
+	"testing"
+
+	"github.com/aiven/terraform-provider-aiven/pkg/aivenapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("business-4", "business-4"))
+	assert.Equal(t, 1, editDistance("business-7", "business-8"))
+	assert.Equal(t, 2, editDistance("busines-16", "business-1"))
+	assert.Equal(t, 10, editDistance("", "business-4"))
+}
+
+func TestCloseMatchesMessage(t *testing.T) {
+	plans := []string{"hobbyist", "startup-4", "business-4", "business-8", "premium-8"}
+
+	assert.Equal(t, ", did you mean business-4 or business-8?", closeMatchesMessage("business-7", plans[2:4]))
+	assert.Equal(t, ", did you mean startup-4?", closeMatchesMessage("startup4", []string{"startup-4"}))
+	assert.Equal(t, "", closeMatchesMessage("xyz", []string{"abc"}))
+	assert.Equal(t, []string{"business-4", "business-8"}, closeMatches("business-7", plans, 3))
+	assert.Equal(t, []string{"business-8"}, closeMatches("bussines8", plans, 1))
+}
+
+func TestServicePlanNames(t *testing.T) {
+	plans := []aivenapi.ServicePlan{
+		{ServicePlan: "startup-4", Regions: map[string]aivenapi.ServicePlanRegion{"google-europe-west1": {}}},
+		{ServicePlan: "business-4", Regions: map[string]aivenapi.ServicePlanRegion{"aws-eu-west-1": {}}},
+	}
+
+	assert.Equal(t, []string{"startup-4", "business-4"}, servicePlanNames(plans, ""))
+	assert.Equal(t, []string{"business-4"}, servicePlanNames(plans, "aws-eu-west-1"))
+	assert.Equal(t, "business-4", findServicePlan(plans, "business-4").ServicePlan)
+	assert.Nil(t, findServicePlan(plans, "business-8"))
+}
+
+func TestServicePlanDiskSpaceMB(t *testing.T) {
+	plan := &aivenapi.ServicePlan{
+		DiskSpaceMB: 81920,
+		Regions:     map[string]aivenapi.ServicePlanRegion{"aws-eu-west-1": {DiskSpaceMB: 122880}, "google-europe-west1": {}},
+	}
+
+	assert.Equal(t, 122880, servicePlanDiskSpaceMB(plan, "aws-eu-west-1"))
+	assert.Equal(t, 81920, servicePlanDiskSpaceMB(plan, "google-europe-west1"))
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+
+package service
+
+import (
+	"github.com/aiven/aiven-go-client"
+)
+
+// ClientProvider is implemented by the meta of the configured provider, the functions of this
+// package get the API client from it
+type ClientProvider interface {
+	AivenClient() *aiven.Client
+}
+
+// getClient returns the API client of the provider meta, ok is false when the provider is not
+// configured, such as in the unit tests of the plan time checks
+func getClient(m interface{}) (*aiven.Client, bool) {
+	p, ok := m.(ClientProvider)
+	if !ok || p == nil {
+		return nil, false
+	}
+	client := p.AivenClient()
+	return client, client != nil
+}
+
+// PlanCacheProvider is implemented by the meta of the configured provider, the service plans are
+// memoised in its cache for the lifetime of the provider instance
+type PlanCacheProvider interface {
+	ServicePlanCache() *PlanCache
+}
+
+// getPlanCache returns the plan cache of the provider meta, nil is returned when the meta has no
+// cache and the plans are then fetched on every call
+func getPlanCache(m interface{}) *PlanCache {
+	if p, ok := m.(PlanCacheProvider); ok && p != nil {
+		return p.ServicePlanCache()
+	}
+	return nil
+}
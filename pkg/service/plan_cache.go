@@ -0,0 +1,105 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+// PlanCache memoises the parameters and the pricing of the service plans, the checks of the disk
+// space and the estimates of the monthly cost of the services share them. Only the successful
+// responses are kept, the calls that fail are made again by the next caller. The zero value is
+// ready to use, a nil cache gets the plans from the API on every call.
+type PlanCache struct {
+	mu         sync.Mutex
+	parameters map[planCacheKey]*planParametersCall
+	pricing    map[planCacheKey]*planPricingCall
+}
+
+type planCacheKey struct {
+	project, serviceType, servicePlan, cloudName string
+}
+
+type planParametersCall struct {
+	once       sync.Once
+	parameters PlanParameters
+	err        error
+}
+
+type planPricingCall struct {
+	once    sync.Once
+	pricing *aiven.GetServicePlanPricingResponse
+	err     error
+}
+
+// Parameters returns the parameters of a service plan
+func (c *PlanCache) Parameters(client *aiven.Client, project, serviceType, servicePlan string) (PlanParameters, error) {
+	if c == nil {
+		return getServicePlanParametersInternal(context.Background(), client, project, serviceType, servicePlan)
+	}
+
+	c.mu.Lock()
+	if c.parameters == nil {
+		c.parameters = make(map[planCacheKey]*planParametersCall)
+	}
+	key := planCacheKey{project: project, serviceType: serviceType, servicePlan: servicePlan}
+	call, ok := c.parameters[key]
+	if !ok {
+		call = &planParametersCall{}
+		c.parameters[key] = call
+	}
+	c.mu.Unlock()
+
+	call.once.Do(func() {
+		call.parameters, call.err = getServicePlanParametersInternal(context.Background(), client, project, serviceType, servicePlan)
+		if call.err != nil {
+			c.mu.Lock()
+			delete(c.parameters, key)
+			c.mu.Unlock()
+		}
+	})
+
+	return call.parameters, call.err
+}
+
+// Pricing returns the pricing of a service plan in a cloud
+func (c *PlanCache) Pricing(client *aiven.Client, project, serviceType, servicePlan, cloudName string) (*aiven.GetServicePlanPricingResponse, error) {
+	if c == nil {
+		return getServicePlanPricing(client, project, serviceType, servicePlan, cloudName)
+	}
+
+	c.mu.Lock()
+	if c.pricing == nil {
+		c.pricing = make(map[planCacheKey]*planPricingCall)
+	}
+	key := planCacheKey{project: project, serviceType: serviceType, servicePlan: servicePlan, cloudName: cloudName}
+	call, ok := c.pricing[key]
+	if !ok {
+		call = &planPricingCall{}
+		c.pricing[key] = call
+	}
+	c.mu.Unlock()
+
+	call.once.Do(func() {
+		call.pricing, call.err = getServicePlanPricing(client, project, serviceType, servicePlan, cloudName)
+		if call.err != nil {
+			c.mu.Lock()
+			delete(c.pricing, key)
+			c.mu.Unlock()
+		}
+	})
+
+	return call.pricing, call.err
+}
+
+func getServicePlanPricing(client *aiven.Client, project, serviceType, servicePlan, cloudName string) (*aiven.GetServicePlanPricingResponse, error) {
+	pricing, err := client.ServiceTypes.GetPlanPricing(project, serviceType, servicePlan, cloudName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get service plan pricing from api: %w", err)
+	}
+	return pricing, nil
+}
@@ -0,0 +1,292 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// upgradeCheckTaskTypes are the service tasks that check whether a service can be upgraded to a new
+// major version, by service type and version option. The other version changes are applied without
+// a pre-check.
+var upgradeCheckTaskTypes = map[string]map[string]string{
+	"pg": {"pg_version": "upgrade_check"},
+}
+
+// VersionKeys returns the user config options of a service type that select the major version of the
+// service, such as `pg_version` or `kafka_version`, according to the user config schema
+func VersionKeys(serviceType string) []string {
+	definition, ok := templates.GetUserConfigSchema("service")[serviceType].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	properties, _ := definition["properties"].(map[string]interface{})
+
+	var keys []string
+	for k, v := range properties {
+		property, ok := v.(map[string]interface{})
+		if !ok || !strings.HasSuffix(k, "_version") {
+			continue
+		}
+		if _, ok := property["enum"]; ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CompareVersions compares two major versions such as `9.6` and `10` by their numeric components, the
+// result is negative when a is older than b, zero when they are equal and positive when a is newer
+func CompareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y string
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+
+		xn, errX := strconv.Atoi(x)
+		yn, errY := strconv.Atoi(y)
+		switch {
+		case errX == nil && errY == nil:
+			if xn != yn {
+				return xn - yn
+			}
+		case x != y:
+			return strings.Compare(x, y)
+		}
+	}
+
+	return 0
+}
+
+// checkVersionChange returns an error when the version of a user config option is downgraded
+func checkVersionChange(key, old, new string) error {
+	if old == "" || new == "" || CompareVersions(new, old) >= 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%s cannot be downgraded from %s to %s, major version upgrades cannot be reverted", key, old, new)
+}
+
+// CustomizeDiffCheckVersionUpgrade fails the plan when the major version of a service, set in the
+// `<type>_user_config` block or the `<type>_user_config_json` attribute, is downgraded
+func CustomizeDiffCheckVersionUpgrade(serviceType string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+
+		serviceType := serviceType
+		if serviceType == "" {
+			serviceType = d.Get("service_type").(string)
+		}
+
+		for _, key := range VersionKeys(serviceType) {
+			old, new := userConfigVersionChange(d, serviceType, key)
+			if err := checkVersionChange(key, old, new); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// userConfigVersionChange returns the old and the new value of a version option, empty strings are
+// returned when the option does not change or when its values are not known
+func userConfigVersionChange(d *schema.ResourceDiff, serviceType, key string) (string, string) {
+	blockKey := serviceType + "_user_config.0." + key
+	if d.HasChange(blockKey) && d.NewValueKnown(blockKey) {
+		old, new := d.GetChange(blockKey)
+		return fmt.Sprint(old), fmt.Sprint(new)
+	}
+
+	jsonKey := serviceType + "_user_config_json"
+	if d.HasChange(jsonKey) && d.NewValueKnown(jsonKey) {
+		old, new := d.GetChange(jsonKey)
+		return userConfigJSONVersion(old, key), userConfigJSONVersion(new, key)
+	}
+
+	return "", ""
+}
+
+// userConfigJSONVersion returns the value of a version option of a JSON encoded user config
+func userConfigJSONVersion(userConfigJSON interface{}, key string) string {
+	s, ok := userConfigJSON.(string)
+	if !ok || s == "" {
+		return ""
+	}
+
+	var userConfig map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &userConfig); err != nil {
+		return ""
+	}
+
+	return userConfigVersion(userConfig, key)
+}
+
+// userConfigVersion returns the value of a version option of a user config in the API format, the
+// versions are strings but numbers are accepted from JSON encoded user configs
+func userConfigVersion(userConfig map[string]interface{}, key string) string {
+	switch v := userConfig[key].(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+// CheckVersionUpgrades checks the major version changes of the user config of a service before the
+// service is updated. Downgrades are refused and the supported upgrade pre-check tasks are run, a
+// failed pre-check is returned as an error and the result of a successful one as a warning.
+func CheckVersionUpgrades(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName, serviceType string,
+	userConfig map[string]interface{},
+	timeout time.Duration,
+) diag.Diagnostics {
+	keys := VersionKeys(serviceType)
+
+	var requested bool
+	for _, key := range keys {
+		requested = requested || userConfigVersion(userConfig, key) != ""
+	}
+	if !requested {
+		return nil
+	}
+
+	s, err := client.Services.Get(project, serviceName)
+	if err != nil {
+		return diag.Errorf("cannot get a service: %s", err)
+	}
+
+	var diags diag.Diagnostics
+	for _, key := range keys {
+		old, new := userConfigVersion(s.UserConfig, key), userConfigVersion(userConfig, key)
+		if old == "" || new == "" || old == new {
+			continue
+		}
+		if err := checkVersionChange(key, old, new); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+
+		taskType, ok := upgradeCheckTaskTypes[serviceType][key]
+		if !ok {
+			continue
+		}
+
+		task, err := runUpgradeCheckTask(ctx, client, project, serviceName, taskType, new, timeout)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+
+		log.Printf("[DEBUG] %s service upgrade check result: %s", serviceType, task.Result)
+		if !*task.Success {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s service upgrade check error, version upgrade from %s to %s", serviceType, old, new),
+				Detail:   task.Result,
+			})
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s service upgrade check passed, version upgrade from %s to %s", serviceType, old, new),
+			Detail:   task.Result,
+		})
+	}
+
+	return diags
+}
+
+// runUpgradeCheckTask creates an upgrade pre-check task and waits until it is done
+func runUpgradeCheckTask(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName, taskType, targetVersion string,
+	timeout time.Duration,
+) (*aiven.ServiceTask, error) {
+	t, err := client.ServiceTask.Create(project, serviceName, aiven.ServiceTaskRequest{
+		TargetVersion: targetVersion,
+		TaskType:      taskType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create %s task: %w", taskType, err)
+	}
+
+	w := &ServiceTaskWaiter{
+		Client:      client,
+		Project:     project,
+		ServiceName: serviceName,
+		TaskId:      t.Task.Id,
+	}
+
+	taskI, err := w.Conf(timeout).WaitForStateContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for Aiven service task to be DONE: %w", err)
+	}
+
+	return &taskI.(*aiven.ServiceTaskResponse).Task, nil
+}
+
+// ServiceTaskWaiter is used to refresh the Aiven Service Task endpoints when
+// provisioning.
+type ServiceTaskWaiter struct {
+	Client      *aiven.Client
+	Project     string
+	ServiceName string
+	TaskId      string
+}
+
+// RefreshFunc will call the Aiven client and refresh its state.
+func (w *ServiceTaskWaiter) RefreshFunc() resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		t, err := w.Client.ServiceTask.Get(
+			w.Project,
+			w.ServiceName,
+			w.TaskId,
+		)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if t.Task.Success == nil {
+			return nil, "IN_PROGRESS", nil
+		}
+
+		return t, "DONE", nil
+	}
+}
+
+// Conf sets up the configuration to refresh.
+func (w *ServiceTaskWaiter) Conf(timeout time.Duration) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending:                   []string{"IN_PROGRESS"},
+		Target:                    []string{"DONE"},
+		Refresh:                   w.RefreshFunc(),
+		Delay:                     10 * time.Second,
+		Timeout:                   timeout,
+		MinTimeout:                2 * time.Second,
+		ContinuousTargetOccurence: 3,
+	}
+}
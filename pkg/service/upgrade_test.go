@@ -0,0 +1,80 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionKeys(t *testing.T) {
+	assert.Equal(t, []string{"pg_version"}, VersionKeys("pg"))
+	assert.Equal(t, []string{"kafka_version"}, VersionKeys("kafka"))
+	assert.Equal(t, []string{"m3_version", "m3db_version"}, VersionKeys("m3db"))
+	assert.Empty(t, VersionKeys("unknown"))
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 0, CompareVersions("13", "13"))
+	assert.Less(t, CompareVersions("9.6", "10"), 0)
+	assert.Greater(t, CompareVersions("2.10", "2.8"), 0)
+	assert.Greater(t, CompareVersions("1.1", "1"), 0)
+	assert.Less(t, CompareVersions("0.15", "1.0"), 0)
+}
+
+func TestCheckVersionChange(t *testing.T) {
+	assert.NoError(t, checkVersionChange("pg_version", "12", "13"))
+	assert.NoError(t, checkVersionChange("pg_version", "", "13"))
+	assert.NoError(t, checkVersionChange("pg_version", "13", ""))
+
+	err := checkVersionChange("pg_version", "13", "9.6")
+	if assert.Error(t, err) {
+		assert.Equal(t, "pg_version cannot be downgraded from 13 to 9.6, major version upgrades cannot be reverted", err.Error())
+	}
+}
+
+func TestUserConfigJSONVersion(t *testing.T) {
+	assert.Equal(t, "13", userConfigJSONVersion(`{"pg_version": "13"}`, "pg_version"))
+	assert.Equal(t, "2.8", userConfigJSONVersion(`{"kafka_version": 2.8}`, "kafka_version"))
+	assert.Equal(t, "", userConfigJSONVersion(`{}`, "pg_version"))
+	assert.Equal(t, "", userConfigJSONVersion("", "pg_version"))
+}
+
+func TestCustomizeDiffCheckVersionUpgrade(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"pg_user_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"pg_version": {Type: schema.TypeString, Optional: true},
+				}},
+			},
+			"pg_user_config_json": {Type: schema.TypeString, Optional: true},
+		},
+		CustomizeDiff: CustomizeDiffCheckVersionUpgrade("pg"),
+	}
+
+	diff := func(attributes map[string]string, config map[string]interface{}) error {
+		state := &terraform.InstanceState{ID: "project/service", Attributes: attributes}
+		_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+		return err
+	}
+
+	block := map[string]string{"pg_user_config.#": "1", "pg_user_config.0.pg_version": "13"}
+	assert.NoError(t, diff(block, map[string]interface{}{
+		"pg_user_config": []interface{}{map[string]interface{}{"pg_version": "14"}},
+	}))
+	assert.Error(t, diff(block, map[string]interface{}{
+		"pg_user_config": []interface{}{map[string]interface{}{"pg_version": "12"}},
+	}))
+
+	json := map[string]string{"pg_user_config_json": `{"pg_version":"13"}`}
+	assert.NoError(t, diff(json, map[string]interface{}{"pg_user_config_json": `{"pg_version":"14"}`}))
+	assert.Error(t, diff(json, map[string]interface{}{"pg_user_config_json": `{"pg_version":"12"}`}))
+}